@@ -0,0 +1,235 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/xpartacvs/go-resellerclub/core"
+)
+
+type actions struct {
+	core core.Core
+}
+
+type Actions interface {
+	SearchCurrent(criteria ActionCriteria, offset, limit uint16) (*ActionSearchResult, error)
+	SearchArchived(criteria ActionCriteria, offset, limit uint16) (*ActionSearchResult, error)
+	Get(eaqID string) (*Action, error)
+	Wait(ctx context.Context, eaqID string, interval time.Duration) (*Action, error)
+}
+
+const (
+	defaultWaitInterval = 10 * time.Second
+	defaultWaitTimeout  = time.Hour
+	maxNotFoundPolls    = 10
+)
+
+var (
+	ErrActionNotFound = errors.New("action not found")
+	ErrActionFailed   = errors.New("action failed")
+)
+
+func New(c core.Core) Actions {
+	return &actions{c}
+}
+
+func (s ActionStatus) IsTerminal() bool {
+	switch s {
+	case StatusSuccess, StatusFailed, StatusCancelled, StatusNoAction:
+		return true
+	}
+	return false
+}
+
+func (s ActionStatus) IsSuccess() bool {
+	return s == StatusSuccess || s == StatusNoAction
+}
+
+func (c ActionCriteria) UrlValues() (url.Values, error) {
+	if err := validator.New().Struct(c); err != nil {
+		return url.Values{}, err
+	}
+
+	data := url.Values{}
+	data["eaq-id"] = append(data["eaq-id"], c.EaqIDs...)
+	data["entity-id"] = append(data["entity-id"], c.EntityIDs...)
+	data["customer-id"] = append(data["customer-id"], c.CustomerIDs...)
+	for _, s := range c.Statuses {
+		data.Add("action-status", string(s))
+	}
+	data["action-type"] = append(data["action-type"], c.Types...)
+
+	for key, values := range data {
+		if len(values) <= 0 {
+			delete(data, key)
+		}
+	}
+	return data, nil
+}
+
+func (a *actions) SearchCurrent(criteria ActionCriteria, offset, limit uint16) (*ActionSearchResult, error) {
+	return a.search("search-current", false, criteria, offset, limit)
+}
+
+func (a *actions) SearchArchived(criteria ActionCriteria, offset, limit uint16) (*ActionSearchResult, error) {
+	return a.search("search-archived", true, criteria, offset, limit)
+}
+
+func (a *actions) Get(eaqID string) (*Action, error) {
+	if !core.RgxNumber.MatchString(eaqID) {
+		return nil, errors.New("invalid eaq id")
+	}
+
+	criteria := ActionCriteria{EaqIDs: []string{eaqID}}
+	for _, search := range []func(ActionCriteria, uint16, uint16) (*ActionSearchResult, error){a.SearchCurrent, a.SearchArchived} {
+		result, err := search(criteria, 1, 10)
+		if err != nil {
+			return nil, err
+		}
+		for _, action := range result.Actions {
+			if action.EaqID == eaqID {
+				return &action, nil
+			}
+		}
+	}
+
+	return nil, ErrActionNotFound
+}
+
+func (a *actions) Wait(ctx context.Context, eaqID string, interval time.Duration) (*Action, error) {
+	if interval <= 0 {
+		interval = defaultWaitInterval
+	}
+
+	if _, ok := ctx.Deadline(); !ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, defaultWaitTimeout)
+		defer cancel()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	notFound := 0
+	for {
+		action, err := a.Get(eaqID)
+		switch {
+		case err == ErrActionNotFound:
+			notFound++
+			if notFound >= maxNotFoundPolls {
+				return nil, err
+			}
+		case err != nil:
+			return nil, err
+		default:
+			notFound = 0
+		}
+		if action != nil && action.Status.IsTerminal() {
+			if !action.Status.IsSuccess() {
+				return action, ErrActionFailed
+			}
+			return action, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return action, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *actions) search(apiName string, archived bool, criteria ActionCriteria, offset, limit uint16) (*ActionSearchResult, error) {
+	if offset <= 0 || limit <= 0 {
+		return nil, errors.New("offset or limit must greater than zero")
+	}
+
+	data, err := criteria.UrlValues()
+	if err != nil {
+		return nil, err
+	}
+	data.Add("no-of-records", strconv.FormatUint(uint64(limit), 10))
+	data.Add("page-no", strconv.FormatUint(uint64(offset), 10))
+
+	resp, err := a.core.CallApi(http.MethodGet, "actions", apiName, data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	result, err := parseSearchResult(bytesResp, archived)
+	if err != nil {
+		return nil, err
+	}
+	result.RequestedLimit = limit
+	result.RequestedOffset = offset
+	return result, nil
+}
+
+func parseSearchResult(bytesResp []byte, archived bool) (*ActionSearchResult, error) {
+	replacer := strings.NewReplacer(`"action.`, `"`, `"entity.`, `"`)
+	strResp := replacer.Replace(string(bytesResp))
+
+	var buffer map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(strResp), &buffer); err != nil {
+		return nil, err
+	}
+
+	result := &ActionSearchResult{Actions: []Action{}}
+	keys := []int{}
+	for key, dataBytes := range buffer {
+		switch {
+		case key == "recsindb":
+			total, err := strconv.Atoi(strings.Trim(string(dataBytes), `"`))
+			if err == nil {
+				result.TotalMatched = total
+			}
+		case key == "result":
+			var list []Action
+			if err := json.Unmarshal(dataBytes, &list); err != nil {
+				return nil, err
+			}
+			result.Actions = append(result.Actions, list...)
+		case core.RgxNumber.MatchString(key):
+			idx, _ := strconv.Atoi(key)
+			keys = append(keys, idx)
+		}
+	}
+
+	sort.Ints(keys)
+	for _, idx := range keys {
+		var action Action
+		if err := json.Unmarshal(buffer[strconv.Itoa(idx)], &action); err != nil {
+			return nil, err
+		}
+		result.Actions = append(result.Actions, action)
+	}
+
+	for i := range result.Actions {
+		result.Actions[i].Archived = archived
+	}
+	return result, nil
+}
@@ -0,0 +1,89 @@
+package actions
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCore struct {
+	calls     int
+	responses map[string][]string
+}
+
+func (f *fakeCore) CallApi(method, namespace, apiName string, data url.Values) (*http.Response, error) {
+	f.calls++
+	body := `{"recsonpage":"0","recsindb":"0"}`
+	if queue := f.responses[apiName]; len(queue) > 0 {
+		body = queue[0]
+		if len(queue) > 1 {
+			f.responses[apiName] = queue[1:]
+		}
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (f *fakeCore) IsProduction() bool {
+	return false
+}
+
+func TestSearchCurrent(t *testing.T) {
+	c := &fakeCore{responses: map[string][]string{
+		"search-current": {`{"recsonpage":"2","recsindb":"2",
+			"2":{"action.eaqid":"11","action.actionstatus":"Pending","entity.entityid":"100"},
+			"1":{"action.eaqid":"10","action.actionstatus":"Success","entity.entityid":"100"}}`},
+	}}
+
+	result, err := New(c).SearchCurrent(ActionCriteria{EntityIDs: []string{"100"}}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, result.TotalMatched)
+	require.Len(t, result.Actions, 2)
+	require.Equal(t, "10", result.Actions[0].EaqID)
+	require.Equal(t, "100", result.Actions[0].EntityID)
+	require.True(t, result.Actions[0].Status.IsTerminal())
+	require.False(t, result.Actions[1].Status.IsTerminal())
+
+	_, err = New(c).SearchCurrent(ActionCriteria{EntityIDs: []string{"abc"}}, 1, 10)
+	require.Error(t, err)
+}
+
+func TestWait(t *testing.T) {
+	c := &fakeCore{responses: map[string][]string{
+		"search-current": {
+			`{"recsindb":"1","1":{"action.eaqid":"10","action.actionstatus":"InProcess"}}`,
+			`{"recsindb":"0"}`,
+		},
+		"search-archived": {`{"recsindb":"1","1":{"action.eaqid":"10","action.actionstatus":"Success"}}`},
+	}}
+
+	action, err := New(c).Wait(context.Background(), "10", time.Millisecond)
+	require.NoError(t, err)
+	require.Equal(t, StatusSuccess, action.Status)
+	require.True(t, action.Archived)
+
+	c = &fakeCore{responses: map[string][]string{
+		"search-current": {`{"recsindb":"1","1":{"action.eaqid":"10","action.actionstatus":"Failed","action.actionstatusdesc":"Rejected by registry"}}`},
+	}}
+	action, err = New(c).Wait(context.Background(), "10", time.Millisecond)
+	require.Equal(t, ErrActionFailed, err)
+	require.Equal(t, "Rejected by registry", action.StatusDescription)
+
+	c = &fakeCore{responses: map[string][]string{
+		"search-current": {`{"recsindb":"1","1":{"action.eaqid":"10","action.actionstatus":"Pending"}}`},
+	}}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = New(c).Wait(ctx, "10", time.Millisecond)
+	require.Equal(t, context.DeadlineExceeded, err)
+
+	c = &fakeCore{}
+	_, err = New(c).Wait(context.Background(), "10", time.Millisecond)
+	require.Equal(t, ErrActionNotFound, err)
+	require.Equal(t, maxNotFoundPolls*2, c.calls)
+}
@@ -0,0 +1,42 @@
+package actions
+
+type ActionStatus string
+
+type Action struct {
+	EaqID             string       `json:"eaqid"`
+	EntityID          string       `json:"entityid"`
+	Type              string       `json:"actiontype"`
+	TypeDescription   string       `json:"actiontypedesc"`
+	Status            ActionStatus `json:"actionstatus"`
+	StatusDescription string       `json:"actionstatusdesc"`
+	Description       string       `json:"description"`
+	CustomerID        string       `json:"customerid"`
+	Archived          bool         `json:"-"`
+}
+
+type ActionCriteria struct {
+	EaqIDs      []string       `validate:"omitempty,dive,number"`
+	EntityIDs   []string       `validate:"omitempty,dive,number"`
+	CustomerIDs []string       `validate:"omitempty,dive,number"`
+	Statuses    []ActionStatus `validate:"omitempty"`
+	Types       []string       `validate:"omitempty"`
+}
+
+type ActionSearchResult struct {
+	RequestedLimit  uint16
+	RequestedOffset uint16
+	TotalMatched    int
+	Actions         []Action
+}
+
+const (
+	StatusSuccess          ActionStatus = "Success"
+	StatusFailed           ActionStatus = "Failed"
+	StatusCancelled        ActionStatus = "Cancelled"
+	StatusPending          ActionStatus = "Pending"
+	StatusInProcess        ActionStatus = "InProcess"
+	StatusPendingExecution ActionStatus = "PendingExecution"
+	StatusPendingCustomer  ActionStatus = "PendingCustomerAction"
+	StatusPendingAdmin     ActionStatus = "PendingAdminApproval"
+	StatusNoAction         ActionStatus = "NoActionRequired"
+)
@@ -0,0 +1,156 @@
+package contact
+
+import (
+	"errors"
+	"time"
+
+	"github.com/xpartacvs/go-resellerclub/core"
+)
+
+type RegistryAttributes interface {
+	DomainKey() core.DomainKey
+	EntityAttributes() (core.EntityAttributes, error)
+}
+
+type DotUSAttributes struct {
+	Purpose  string
+	Category string
+}
+
+type DotCAAttributes struct {
+	CPR              string
+	AgreementVersion string
+	Agreed           bool
+}
+
+type DotESAttributes struct {
+	LegalForm            string
+	IdentificationType   string
+	IdentificationNumber string
+}
+
+type DotRUAttributes struct {
+	ContractType string
+	BirthDate    time.Time
+	PersonName   string
+	Passport     string
+	OrgName      string
+	TaxCode      string
+	KPP          string
+	Address      string
+}
+
+type DotASIAAttributes struct {
+	Locality             string
+	LegalEntityType      string
+	OtherLegalEntityType string
+	IdentForm            string
+	OtherIdentForm       string
+	IdentNumber          string
+}
+
+func (a DotUSAttributes) DomainKey() core.DomainKey {
+	return core.DotUS
+}
+
+func (a DotUSAttributes) EntityAttributes() (core.EntityAttributes, error) {
+	return buildAttributes(a.DomainKey(), map[string]string{
+		"purpose":  a.Purpose,
+		"category": a.Category,
+	})
+}
+
+func (a DotCAAttributes) DomainKey() core.DomainKey {
+	return core.DotCA
+}
+
+func (a DotCAAttributes) EntityAttributes() (core.EntityAttributes, error) {
+	if !a.Agreed {
+		return nil, errors.New("cira agreement must be accepted")
+	}
+	return buildAttributes(a.DomainKey(), map[string]string{
+		"CPR":              a.CPR,
+		"AgreementVersion": a.AgreementVersion,
+		"AgreementValue":   "y",
+	})
+}
+
+func (a DotESAttributes) DomainKey() core.DomainKey {
+	return core.DotES
+}
+
+func (a DotESAttributes) EntityAttributes() (core.EntityAttributes, error) {
+	return buildAttributes(a.DomainKey(), map[string]string{
+		"es_form_juridica":       a.LegalForm,
+		"es_tipo_identificacion": a.IdentificationType,
+		"es_identificacion":      a.IdentificationNumber,
+	})
+}
+
+func (a DotRUAttributes) DomainKey() core.DomainKey {
+	return core.DotRU
+}
+
+func (a DotRUAttributes) EntityAttributes() (core.EntityAttributes, error) {
+	birthDate := ""
+	if !a.BirthDate.IsZero() {
+		birthDate = a.BirthDate.Format("02.01.2006")
+	}
+	return buildAttributes(a.DomainKey(), map[string]string{
+		"contract-type": a.ContractType,
+		"birth-date":    birthDate,
+		"person-r-name": a.PersonName,
+		"passport":      a.Passport,
+		"org-r":         a.OrgName,
+		"code":          a.TaxCode,
+		"kpp":           a.KPP,
+		"address-r":     a.Address,
+	})
+}
+
+func (a DotASIAAttributes) DomainKey() core.DomainKey {
+	return core.DotASIA
+}
+
+func (a DotASIAAttributes) EntityAttributes() (core.EntityAttributes, error) {
+	return buildAttributes(a.DomainKey(), map[string]string{
+		"locality":             a.Locality,
+		"legalentitytype":      a.LegalEntityType,
+		"otherlegalentitytype": a.OtherLegalEntityType,
+		"identform":            a.IdentForm,
+		"otheridentform":       a.OtherIdentForm,
+		"identnumber":          a.IdentNumber,
+	})
+}
+
+func MergeRegistryAttributes(attrs ...RegistryAttributes) (core.EntityAttributes, []core.DomainKey, error) {
+	merged := core.NewEntityAttributes()
+	domainKeys := []core.DomainKey{}
+	for _, a := range attrs {
+		ea, err := a.EntityAttributes()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, key := range ea.Keys() {
+			if existing := merged.Get(key); len(existing) > 0 && existing != ea.Get(key) {
+				return nil, nil, errors.New("conflicting value for attribute " + key)
+			}
+			merged.Add(key, ea.Get(key))
+		}
+		domainKeys = append(domainKeys, a.DomainKey())
+	}
+	return merged, domainKeys, nil
+}
+
+func buildAttributes(key core.DomainKey, values map[string]string) (core.EntityAttributes, error) {
+	attrs := core.NewEntityAttributes()
+	for name, value := range values {
+		if len(value) > 0 {
+			attrs.Add(name, value)
+		}
+	}
+	if err := RequirementOf(key).ValidateAttributes(attrs); err != nil {
+		return nil, err
+	}
+	return attrs, nil
+}
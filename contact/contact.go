@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,14 +23,15 @@ type Contact interface {
 	Add(details *ContactDetail, attributes core.EntityAttributes) error
 	Details(contactId string) (*ContactDetail, error)
 	Delete(contactId string) (*Action, error)
+	Modify(contactId string, modification ContactDetail) (*Action, error)
 	Search(criteria ContactCriteria, offset, limit uint16) (*ContactSearchResult, error)
 	SetDefault(customerId, registrantContactID, adminContactID, techContactID, billingContactID string, types []ContactType) error
 	Default(customerId string, types []ContactType) (map[string]ContactDetail, error)
 	ValidateRegistrant(contactId string, eligibilities []Eligibility) (RegistrantValidation, error)
 	AddExtraDetails(contactId string, attributes core.EntityAttributes, domainKeys []core.DomainKey) error
 	DotCAAgreement() (map[string]string, error)
-	// AddDotCOOPSponsor(customerId string, details ContactDetail) (string, error)
-	// DotCOOPSponsors(customerId string) error
+	AddDotCOOPSponsor(customerId string, details ContactDetail) (string, error)
+	DotCOOPSponsors(customerId string) ([]ContactDetail, error)
 }
 
 func (c *contact) DotCAAgreement() (map[string]string, error) {
@@ -61,71 +63,108 @@ func (c *contact) DotCAAgreement() (map[string]string, error) {
 	return ret, nil
 }
 
-// func (c *contact) DotCOOPSponsors(customerId string) error {
-// 	if !core.RgxNumber.MatchString(customerId) {
-// 		return core.ErrRcInvalidCredential
-// 	}
-
-// 	resp, err := c.core.CallApi(http.MethodGet, "contacts", "sponsors", url.Values{"customer-id": []string{customerId}})
-// 	if err != nil {
-// 		return err
-// 	}
-// 	defer resp.Body.Close()
-
-// 	bytesResp, err := io.ReadAll(resp.Body)
-// 	if err != nil {
-// 		return err
-// 	}
-
-// 	if resp.StatusCode != http.StatusOK {
-// 		errResponse := core.JSONStatusResponse{}
-// 		err = json.Unmarshal(bytesResp, &errResponse)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		return errors.New(strings.ToLower(errResponse.Message))
-// 	}
-
-// 	return nil
-// }
-
-// func (c *contact) AddDotCOOPSponsor(customerId string, details ContactDetail) (string, error) {
-// 	if !core.RgxNumber.MatchString(customerId) {
-// 		return "", core.ErrRcInvalidCredential
-// 	}
-
-// 	if !core.RgxEmail.MatchString(details.Email) {
-// 		return "", errors.New("invalid format for email")
-// 	}
-
-// 	data, err := extractSponsorData(details)
-// 	if err != nil {
-// 		return "", err
-// 	}
-// 	data.Add("customer-id", customerId)
-
-// 	resp, err := c.core.CallApi(http.MethodPost, "contacts/coop", "add-sponsor", *data)
-// 	if err != nil {
-// 		return "", err
-// 	}
-// 	defer resp.Body.Close()
-
-// 	bytesResp, err := io.ReadAll(resp.Body)
-// 	if err != nil {
-// 		return "", err
-// 	}
-
-// 	if resp.StatusCode != http.StatusOK {
-// 		errResponse := core.JSONStatusResponse{}
-// 		err = json.Unmarshal(bytesResp, &errResponse)
-// 		if err != nil {
-// 			return "", err
-// 		}
-// 		return "", errors.New(strings.ToLower(errResponse.Message))
-// 	}
-
-// 	return string(bytesResp), nil
-// }
+func (c *contact) DotCOOPSponsors(customerId string) ([]ContactDetail, error) {
+	if !core.RgxNumber.MatchString(customerId) {
+		return nil, core.ErrRcInvalidCredential
+	}
+
+	resp, err := c.core.CallApi(http.MethodGet, "contacts", "sponsors", url.Values{"customer-id": []string{customerId}})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	replacer := strings.NewReplacer("contact.", "", "entity.", "")
+	bytesResp = []byte(replacer.Replace(string(bytesResp)))
+
+	sponsors := []ContactDetail{}
+	if err := json.Unmarshal(bytesResp, &sponsors); err == nil {
+		return sponsors, nil
+	}
+
+	buffer := map[string]core.JSONBytes{}
+	if err := json.Unmarshal(bytesResp, &buffer); err != nil {
+		return nil, err
+	}
+
+	keys := []int{}
+	for key := range buffer {
+		if idx, err := strconv.Atoi(key); err == nil {
+			keys = append(keys, idx)
+		}
+	}
+	sort.Ints(keys)
+
+	for _, idx := range keys {
+		sponsor := ContactDetail{}
+		if err := json.Unmarshal(buffer[strconv.Itoa(idx)], &sponsor); err != nil {
+			return nil, err
+		}
+		sponsors = append(sponsors, sponsor)
+	}
+
+	return sponsors, nil
+}
+
+func (c *contact) AddDotCOOPSponsor(customerId string, details ContactDetail) (string, error) {
+	if !core.RgxNumber.MatchString(customerId) {
+		return "", core.ErrRcInvalidCredential
+	}
+
+	if !core.RgxEmail.MatchString(details.Email) {
+		return "", errors.New("invalid format for email")
+	}
+	if err := details.NormalizePhones(); err != nil {
+		return "", err
+	}
+
+	data, err := extractSponsorData(details)
+	if err != nil {
+		return "", err
+	}
+	data.Add("customer-id", customerId)
+
+	resp, err := c.core.CallApi(http.MethodPost, "contacts/coop", "add-sponsor", *data)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return "", err
+		}
+		return "", errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	sponsorId := strings.Trim(strings.TrimSpace(string(bytesResp)), "\"")
+	if !core.RgxNumber.MatchString(sponsorId) {
+		return "", core.ErrRcOperationFailed
+	}
+
+	return sponsorId, nil
+}
 
 func (c *contact) AddExtraDetails(contactId string, attributes core.EntityAttributes, domainKeys []core.DomainKey) error {
 	if !core.RgxNumber.MatchString(contactId) {
@@ -136,22 +175,21 @@ func (c *contact) AddExtraDetails(contactId string, attributes core.EntityAttrib
 		return errors.New("attributes and domain keys cannot be nil or empty")
 	}
 
+	details, err := c.Details(contactId)
+	if err != nil {
+		return err
+	}
+	if err := ValidateForDomainKeys(details, attributes, domainKeys); err != nil {
+		return err
+	}
+
 	data := url.Values{}
 	data.Add("contact-id", contactId)
 	attributes.CopyTo(&data)
 
-	wg := sync.WaitGroup{}
-	rwMutex := sync.RWMutex{}
 	for _, k := range domainKeys {
-		wg.Add(1)
-		go func(key string) {
-			defer wg.Done()
-			rwMutex.Lock()
-			data.Add("product-key", key)
-			rwMutex.Unlock()
-		}(string(k))
+		data.Add("product-key", string(k))
 	}
-	wg.Wait()
 
 	resp, err := c.core.CallApi(http.MethodPost, "contacts", "set-details", data)
 	if err != nil {
@@ -465,6 +503,62 @@ func (c *contact) Delete(contactId string) (*Action, error) {
 	return ret, nil
 }
 
+func (c *contact) Modify(contactId string, modification ContactDetail) (*Action, error) {
+	if !core.RgxNumber.MatchString(contactId) {
+		return nil, core.ErrRcInvalidCredential
+	}
+
+	contactBefore, err := c.Details(contactId)
+	if err != nil {
+		return nil, err
+	}
+
+	phoneChanged := len(modification.Phone) > 0 || len(modification.PhoneCountryCode) > 0 || len(modification.Fax) > 0
+	if err := modification.mergePrevious(contactBefore); err != nil {
+		return nil, err
+	}
+	if phoneChanged {
+		if err := modification.NormalizePhones(); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := modification.UrlValues()
+	if err != nil {
+		return nil, err
+	}
+	data.Del("customer-id")
+	data.Del("type")
+	data.Add("contact-id", contactId)
+
+	resp, err := c.core.CallApi(http.MethodPost, "contacts", "modify", *data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	ret := new(Action)
+	if err = json.Unmarshal(bytesResp, ret); err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
 func (c *contact) Details(contactId string) (*ContactDetail, error) {
 	if !core.RgxNumber.MatchString(contactId) {
 		return nil, core.ErrRcInvalidCredential
@@ -505,6 +599,9 @@ func (c *contact) Add(details *ContactDetail, attributes core.EntityAttributes)
 	if details == nil {
 		return errors.New("detail must not nil")
 	}
+	if err := details.NormalizePhones(); err != nil {
+		return err
+	}
 
 	data, err := details.UrlValues()
 	if err != nil {
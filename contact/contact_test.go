@@ -0,0 +1,244 @@
+package contact
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xpartacvs/go-resellerclub/core"
+	"github.com/xpartacvs/go-resellerclub/domain"
+)
+
+type fakeCore struct {
+	responses map[string]string
+	requests  map[string]url.Values
+}
+
+func (f *fakeCore) CallApi(method, namespace, apiName string, data url.Values) (*http.Response, error) {
+	if f.requests == nil {
+		f.requests = map[string]url.Values{}
+	}
+	f.requests[namespace+"/"+apiName] = data
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(f.responses[namespace+"/"+apiName]))}, nil
+}
+
+func (f *fakeCore) IsProduction() bool {
+	return false
+}
+
+func TestModify(t *testing.T) {
+	c := &fakeCore{responses: map[string]string{
+		"contacts/details": `{"entityid":"10","type":"Contact","customerid":"1","name":"Jane Doe","emailaddr":"jane@example.com","company":"Example","address1":"1 Main St","city":"Springfield","country":"US","zip":"12345","telnocc":"1","telno":"5550100"}`,
+		"contacts/modify":  `{"eaqid":"99","entityid":"10","actionstatus":"Success"}`,
+	}}
+
+	action, err := New(c).Modify("10", ContactDetail{Address: "2 Main St"})
+	require.NoError(t, err)
+	require.Equal(t, "99", action.Id)
+
+	sent := c.requests["contacts/modify"]
+	require.Equal(t, "10", sent.Get("contact-id"))
+	require.Equal(t, "2 Main St", sent.Get("address-line-1"))
+	require.Equal(t, "Jane Doe", sent.Get("name"))
+	require.Empty(t, sent.Get("customer-id"))
+	require.Empty(t, sent.Get("type"))
+
+	_, err = New(c).Modify("10", ContactDetail{Email: "not-an-email"})
+	require.Error(t, err)
+
+	_, err = New(c).Modify("abc", ContactDetail{})
+	require.Error(t, err)
+}
+
+func TestDotCOOPSponsors(t *testing.T) {
+	c := &fakeCore{responses: map[string]string{
+		"contacts/coop/add-sponsor": "12345",
+		"contacts/sponsors":         `{"2":{"contact.contactid":"2","contact.name":"Second"},"1":{"contact.contactid":"1","contact.name":"First"}}`,
+	}}
+
+	sponsor := ContactDetail{
+		Name:             "Jane Doe",
+		Email:            "jane@example.coop",
+		Company:          "Example Coop",
+		Address:          "1 Main St",
+		City:             "Springfield",
+		CountryCode:      "US",
+		Zipcode:          "12345",
+		PhoneCountryCode: "1",
+		Phone:            "(217) 555-0100",
+	}
+	id, err := New(c).AddDotCOOPSponsor("1", sponsor)
+	require.NoError(t, err)
+	require.Equal(t, "12345", id)
+	require.Equal(t, "Example Coop", c.requests["contacts/coop/add-sponsor"].Get("company"))
+	require.Equal(t, "2175550100", c.requests["contacts/coop/add-sponsor"].Get("phone"))
+
+	sponsor.City = ""
+	_, err = New(c).AddDotCOOPSponsor("1", sponsor)
+	require.Error(t, err)
+
+	sponsors, err := New(c).DotCOOPSponsors("1")
+	require.NoError(t, err)
+	require.Len(t, sponsors, 2)
+	require.Equal(t, "First", sponsors[0].Name)
+	require.Equal(t, "2", sponsors[1].ContactId)
+}
+
+func TestAddExtraDetails(t *testing.T) {
+	c := &fakeCore{responses: map[string]string{
+		"contacts/details":     `{"entityid":"10","type":"Contact","customerid":"1","name":"Jane Doe","country":"US"}`,
+		"contacts/set-details": "true",
+	}}
+
+	attrs := core.NewEntityAttributes()
+	attrs.Add("purpose", "P1")
+	require.Error(t, New(c).AddExtraDetails("10", attrs, []core.DomainKey{core.DotUS}))
+	require.Nil(t, c.requests["contacts/set-details"])
+
+	attrs.Add("category", "C11")
+	require.NoError(t, New(c).AddExtraDetails("10", attrs, []core.DomainKey{core.DotUS}))
+	require.Equal(t, string(core.DotUS), c.requests["contacts/set-details"].Get("product-key"))
+
+	require.Error(t, New(c).AddExtraDetails("10", attrs, []core.DomainKey{core.DotCA}))
+}
+
+func TestRequirementValidate(t *testing.T) {
+	details := &ContactDetail{Type: TypeCa, CountryCode: "CA"}
+	attrs := core.NewEntityAttributes()
+	attrs.Add("CPR", "CCT")
+	attrs.Add("AgreementVersion", "2.0")
+	attrs.Add("AgreementValue", "y")
+	require.NoError(t, RequirementOf(core.DotCA).Validate(details, attrs))
+
+	attrs.Add("CPR", "XXX")
+	require.Error(t, RequirementOf(core.DotCA).Validate(details, attrs))
+
+	require.Error(t, RequirementOf(core.DotEU).Validate(&ContactDetail{Type: TypeEu, CountryCode: "US"}, nil))
+	require.NoError(t, RequirementOf(core.DotEU).Validate(&ContactDetail{Type: TypeEu, CountryCode: "de"}, nil))
+	require.Error(t, RequirementOf(core.DotUK).Validate(&ContactDetail{Type: TypeContact}, nil))
+
+	ru := core.NewEntityAttributes()
+	ru.Add("contract-type", "PRS")
+	ru.Add("address-r", "Moscow")
+	require.Error(t, RequirementOf(core.DotRU).Validate(&ContactDetail{Type: TypeContact}, ru))
+	ru.Add("birth-date", "01.02.1990")
+	ru.Add("person-r-name", "Ivan Ivanov")
+	ru.Add("passport", "1234 567890")
+	require.NoError(t, RequirementOf(core.DotRU).Validate(&ContactDetail{Type: TypeContact}, ru))
+
+	require.Error(t, ValidateForDomainKeys(details, attrs, []core.DomainKey{core.DotUS}))
+}
+
+func TestRegistryAttributes(t *testing.T) {
+	attrs, keys, err := MergeRegistryAttributes(
+		DotUSAttributes{Purpose: "P1", Category: "C11"},
+		DotRUAttributes{ContractType: "PRS", BirthDate: time.Date(1990, 2, 1, 0, 0, 0, 0, time.UTC), PersonName: "Ivan Ivanov", Passport: "1234 567890", Address: "Moscow"},
+	)
+	require.NoError(t, err)
+	require.Equal(t, []core.DomainKey{core.DotUS, core.DotRU}, keys)
+	require.Equal(t, "01.02.1990", attrs.Get("birth-date"))
+
+	values := attrs.UrlValues()
+	require.Equal(t, "address-r", values.Get("attr-name1"))
+	require.Equal(t, "Moscow", values.Get("attr-value1"))
+	require.Equal(t, "birth-date", values.Get("attr-name2"))
+	require.Equal(t, "purpose", values.Get("attr-name7"))
+	require.Equal(t, "P1", values.Get("attr-value7"))
+
+	_, err = DotUSAttributes{Purpose: "P9", Category: "C11"}.EntityAttributes()
+	require.Error(t, err)
+	_, err = DotCAAttributes{CPR: "CCT", AgreementVersion: "2.0"}.EntityAttributes()
+	require.Error(t, err)
+}
+
+type fakeDedupContact struct {
+	Contact
+	contacts []ContactDetail
+	deleted  []string
+	criteria []ContactCriteria
+}
+
+func (f *fakeDedupContact) Search(criteria ContactCriteria, offset, limit uint16) (*ContactSearchResult, error) {
+	f.criteria = append(f.criteria, criteria)
+	return &ContactSearchResult{TotalMatched: len(f.contacts), Contacts: f.contacts}, nil
+}
+
+func (f *fakeDedupContact) Delete(contactId string) (*Action, error) {
+	f.deleted = append(f.deleted, contactId)
+	return &Action{EntityId: contactId, Status: "Success"}, nil
+}
+
+type fakeDedupDomain struct {
+	domain.Domain
+	orders   map[string]*domain.OrderDetail
+	modified map[string][]string
+	flags    map[string][2]bool
+}
+
+func (f *fakeDedupDomain) SearchOrderSummaries(criteria domain.OrderCriteria, offset, limit uint16) (*domain.OrderSearchResult, error) {
+	result := &domain.OrderSearchResult{TotalMatched: len(f.orders)}
+	for id, o := range f.orders {
+		result.Orders = append(result.Orders, domain.OrderSummary{OrderID: id, DomainName: o.DomainName})
+	}
+	return result, nil
+}
+
+func (f *fakeDedupDomain) GetRegistrationOrderDetails(orderID string, options []string) (*domain.OrderDetail, error) {
+	return f.orders[orderID], nil
+}
+
+func (f *fakeDedupDomain) ModifyContacts(orderID, regContactID, adminContactID, techContactID, billingContactID string, sixtyDayLockOptout, designatedAgent bool, attrName, attrValue string) (*domain.ModifyAuthCodeResponse, error) {
+	f.modified[orderID] = []string{regContactID, adminContactID, techContactID, billingContactID}
+	f.flags[orderID] = [2]bool{sixtyDayLockOptout, designatedAgent}
+	return &domain.ModifyAuthCodeResponse{ActionStatus: "Success"}, nil
+}
+
+func TestDeduplicator(t *testing.T) {
+	jane := ContactDetail{Type: TypeContact, CustomerId: "1", Name: "Jane Doe", Email: "jane@example.com", Company: "Example", Address: "1 Main St.", City: "Springfield", CountryCode: "US", Zipcode: "12345", PhoneCountryCode: "1", Phone: "5550100"}
+	c1, c2, c3 := jane, jane, jane
+	c1.Id, c2.Id, c3.Id = "12", "10", "11"
+	c2.Name = "JANE  doe"
+	c3.Address = "1 Main St"
+	other := jane
+	other.Id, other.Name = "20", "John Doe"
+
+	fc := &fakeDedupContact{contacts: []ContactDetail{c1, c2, c3, other}}
+	fd := &fakeDedupDomain{
+		orders: map[string]*domain.OrderDetail{
+			"100": {DomainName: "one.com", RegistrantContactID: "12", AdminContactID: "11", TechContactID: "20", BillingContactID: "10"},
+			"200": {DomainName: "two.com", RegistrantContactID: "10", AdminContactID: "10", TechContactID: "20", BillingContactID: "20"},
+		},
+		modified: map[string][]string{},
+		flags:    map[string][2]bool{},
+	}
+
+	dedup := NewDeduplicator(fc, fd)
+	plan, err := dedup.Plan("1")
+	require.NoError(t, err)
+	require.Len(t, plan.Groups, 1)
+	require.Equal(t, "10", plan.Groups[0].Canonical.Id)
+	require.Equal(t, []string{"11", "12"}, plan.Deletions)
+	require.Len(t, plan.Repoints, 1)
+	require.Equal(t, "100", plan.Repoints[0].OrderID)
+	require.False(t, plan.SixtyDayLockOptout)
+	require.False(t, plan.DesignatedAgent)
+	require.True(t, fc.criteria[0].IsIncludeInvalid)
+	require.Empty(t, fd.modified)
+	require.Empty(t, fc.deleted)
+
+	result, err := dedup.Apply(plan)
+	require.NoError(t, err)
+	require.Empty(t, result.Failed)
+	require.Equal(t, []string{"10", "10", "20", "10"}, fd.modified["100"])
+	require.Equal(t, [2]bool{false, false}, fd.flags["100"])
+	require.Equal(t, []string{"11", "12"}, fc.deleted)
+
+	plan.SixtyDayLockOptout, plan.DesignatedAgent = true, true
+	_, err = dedup.Apply(plan)
+	require.NoError(t, err)
+	require.Equal(t, [2]bool{true, true}, fd.flags["100"])
+}
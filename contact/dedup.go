@@ -0,0 +1,288 @@
+package contact
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/xpartacvs/go-resellerclub/core"
+	"github.com/xpartacvs/go-resellerclub/domain"
+)
+
+type DuplicateGroup struct {
+	Key        string
+	Canonical  ContactDetail
+	Duplicates []ContactDetail
+}
+
+type ContactRepoint struct {
+	OrderID          string
+	DomainName       string
+	RegContactID     string
+	AdminContactID   string
+	TechContactID    string
+	BillingContactID string
+}
+
+type DedupPlan struct {
+	CustomerId         string
+	Groups             []DuplicateGroup
+	Repoints           []ContactRepoint
+	Deletions          []string
+	SixtyDayLockOptout bool
+	DesignatedAgent    bool
+}
+
+type DedupResult struct {
+	Repointed []string
+	Deleted   []string
+	Failed    map[string]error
+}
+
+type Deduplicator interface {
+	Plan(customerId string) (*DedupPlan, error)
+	Apply(plan *DedupPlan) (*DedupResult, error)
+}
+
+type deduplicator struct {
+	contact Contact
+	domain  domain.Domain
+}
+
+const dedupPageSize = 100
+
+func NewDeduplicator(c Contact, d domain.Domain) Deduplicator {
+	return &deduplicator{
+		contact: c,
+		domain:  d,
+	}
+}
+
+func (p *DedupPlan) IsEmpty() bool {
+	return len(p.Repoints) <= 0 && len(p.Deletions) <= 0
+}
+
+func (c *ContactDetail) DedupKey() string {
+	fields := []string{
+		string(c.Type),
+		c.Name,
+		c.Email,
+		c.Company,
+		c.Address,
+		c.AddressLine2,
+		c.AddressLine3,
+		c.City,
+		c.State,
+		c.CountryCode,
+		c.Zipcode,
+		c.PhoneCountryCode + c.Phone,
+	}
+	for i, f := range fields {
+		fields[i] = normalizeField(f)
+	}
+	fields[2] = strings.ToLower(strings.TrimSpace(c.Email))
+	return strings.Join(fields, "|")
+}
+
+func (x *deduplicator) Plan(customerId string) (*DedupPlan, error) {
+	if !core.RgxNumber.MatchString(customerId) {
+		return nil, core.ErrRcInvalidCredential
+	}
+
+	contacts, err := x.customerContacts(customerId)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := map[string][]ContactDetail{}
+	keys := []string{}
+	for _, c := range contacts {
+		if core.EntityStatus(c.StatusSystem) == core.StatusDeleted {
+			continue
+		}
+		key := c.DedupKey()
+		if _, ok := grouped[key]; !ok {
+			keys = append(keys, key)
+		}
+		grouped[key] = append(grouped[key], c)
+	}
+	sort.Strings(keys)
+
+	plan := &DedupPlan{
+		CustomerId: customerId,
+		Groups:     []DuplicateGroup{},
+		Repoints:   []ContactRepoint{},
+		Deletions:  []string{},
+	}
+	replacement := map[string]string{}
+	for _, key := range keys {
+		members := grouped[key]
+		if len(members) < 2 {
+			continue
+		}
+		sort.SliceStable(members, func(i, j int) bool {
+			return preferContact(&members[i], &members[j])
+		})
+		group := DuplicateGroup{
+			Key:        key,
+			Canonical:  members[0],
+			Duplicates: members[1:],
+		}
+		for _, dup := range group.Duplicates {
+			replacement[dup.Id] = group.Canonical.Id
+			plan.Deletions = append(plan.Deletions, dup.Id)
+		}
+		plan.Groups = append(plan.Groups, group)
+	}
+
+	if len(replacement) <= 0 {
+		return plan, nil
+	}
+
+	orders, err := x.customerOrders(customerId)
+	if err != nil {
+		return nil, err
+	}
+	for _, order := range orders {
+		if core.EntityStatus(order.CurrentStatus) == core.StatusDeleted {
+			continue
+		}
+		detail, err := x.domain.GetRegistrationOrderDetails(order.OrderID, []string{"ContactIds"})
+		if err != nil {
+			return nil, err
+		}
+
+		repoint := ContactRepoint{
+			OrderID:          order.OrderID,
+			DomainName:       order.DomainName,
+			RegContactID:     replaceContactID(replacement, detail.RegistrantContactID),
+			AdminContactID:   replaceContactID(replacement, detail.AdminContactID),
+			TechContactID:    replaceContactID(replacement, detail.TechContactID),
+			BillingContactID: replaceContactID(replacement, detail.BillingContactID),
+		}
+		if repoint.RegContactID != detail.RegistrantContactID ||
+			repoint.AdminContactID != detail.AdminContactID ||
+			repoint.TechContactID != detail.TechContactID ||
+			repoint.BillingContactID != detail.BillingContactID {
+			plan.Repoints = append(plan.Repoints, repoint)
+		}
+	}
+
+	return plan, nil
+}
+
+func (x *deduplicator) Apply(plan *DedupPlan) (*DedupResult, error) {
+	if plan == nil {
+		return nil, errors.New("plan must not nil")
+	}
+
+	result := &DedupResult{
+		Repointed: []string{},
+		Deleted:   []string{},
+		Failed:    map[string]error{},
+	}
+
+	blocked := map[string]bool{}
+	for _, r := range plan.Repoints {
+		resp, err := x.domain.ModifyContacts(r.OrderID, r.RegContactID, r.AdminContactID, r.TechContactID, r.BillingContactID, plan.SixtyDayLockOptout, plan.DesignatedAgent, "", "")
+		if err == nil && (strings.EqualFold(resp.ActionStatus, "failed") || strings.EqualFold(resp.Status, "error")) {
+			err = errors.New(strings.ToLower(resp.ActionStatusDesc))
+		}
+		if err != nil {
+			result.Failed["order "+r.OrderID] = err
+			detail, derr := x.domain.GetRegistrationOrderDetails(r.OrderID, []string{"ContactIds"})
+			if derr != nil {
+				return result, derr
+			}
+			blocked[detail.RegistrantContactID] = true
+			blocked[detail.AdminContactID] = true
+			blocked[detail.TechContactID] = true
+			blocked[detail.BillingContactID] = true
+			continue
+		}
+		result.Repointed = append(result.Repointed, r.OrderID)
+	}
+
+	for _, id := range plan.Deletions {
+		if blocked[id] {
+			result.Failed["contact "+id] = fmt.Errorf("contact %s is still assigned to an order", id)
+			continue
+		}
+		action, err := x.contact.Delete(id)
+		if err == nil && strings.EqualFold(action.Status, "failed") {
+			err = errors.New(strings.ToLower(action.StatusDescription))
+		}
+		if err != nil {
+			result.Failed["contact "+id] = err
+			continue
+		}
+		result.Deleted = append(result.Deleted, id)
+	}
+
+	return result, nil
+}
+
+func (x *deduplicator) customerContacts(customerId string) ([]ContactDetail, error) {
+	contacts := []ContactDetail{}
+	for page := uint16(1); ; page++ {
+		result, err := x.contact.Search(ContactCriteria{CustomerId: customerId, IsIncludeInvalid: true}, page, dedupPageSize)
+		if err != nil {
+			return nil, err
+		}
+		contacts = append(contacts, result.Contacts...)
+		if len(result.Contacts) <= 0 || len(contacts) >= result.TotalMatched {
+			return contacts, nil
+		}
+	}
+}
+
+func (x *deduplicator) customerOrders(customerId string) ([]domain.OrderSummary, error) {
+	criteria := domain.OrderCriteria{Criteria: core.Criteria{CustomerIDs: []string{customerId}}}
+	orders := []domain.OrderSummary{}
+	for page := uint16(1); ; page++ {
+		result, err := x.domain.SearchOrderSummaries(criteria, page, dedupPageSize)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, result.Orders...)
+		if len(result.Orders) <= 0 || len(orders) >= result.TotalMatched {
+			return orders, nil
+		}
+	}
+}
+
+func preferContact(a, b *ContactDetail) bool {
+	if a.WhoisValidity.IsValid != b.WhoisValidity.IsValid {
+		return bool(a.WhoisValidity.IsValid)
+	}
+	ta, tb := a.TimeCreation.ToTime(), b.TimeCreation.ToTime()
+	if !ta.Equal(tb) {
+		return ta.Before(tb)
+	}
+	ia, erra := strconv.ParseUint(a.Id, 10, 64)
+	ib, errb := strconv.ParseUint(b.Id, 10, 64)
+	if erra == nil && errb == nil {
+		return ia < ib
+	}
+	return a.Id < b.Id
+}
+
+func replaceContactID(replacement map[string]string, id string) string {
+	if r, ok := replacement[id]; ok {
+		return r
+	}
+	return id
+}
+
+func normalizeField(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsPunct(r) {
+			return ' '
+		}
+		return unicode.ToLower(r)
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
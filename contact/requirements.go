@@ -0,0 +1,226 @@
+package contact
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/xpartacvs/go-resellerclub/core"
+)
+
+type AttributeCondition struct {
+	Name  string
+	Value string
+}
+
+type AttributeRule struct {
+	Name         string
+	Required     bool
+	Allowed      []string
+	Pattern      *regexp.Regexp
+	RequiredWhen *AttributeCondition
+}
+
+type ContactRequirement struct {
+	DomainKey     core.DomainKey
+	ContactType   ContactType
+	Countries     []string
+	States        []string
+	Attributes    []AttributeRule
+	Eligibilities []Eligibility
+}
+
+var (
+	countriesEU = []string{
+		"AT", "BE", "BG", "CY", "CZ", "DE", "DK", "EE", "ES", "FI", "FR", "GR", "HR", "HU", "IE", "IT", "LT",
+		"LU", "LV", "MT", "NL", "PL", "PT", "RO", "SE", "SI", "SK", "IS", "LI", "NO",
+	}
+
+	Requirements = map[core.DomainKey]ContactRequirement{
+		core.DotUS: {
+			DomainKey:   core.DotUS,
+			ContactType: TypeContact,
+			Attributes: []AttributeRule{
+				{Name: "purpose", Required: true, Allowed: []string{"P1", "P2", "P3", "P4", "P5"}},
+				{Name: "category", Required: true, Allowed: []string{"C11", "C12", "C21", "C31", "C32"}},
+			},
+			Eligibilities: []Eligibility{EligibilityDotUS},
+		},
+		core.DotCA: {
+			DomainKey:   core.DotCA,
+			ContactType: TypeCa,
+			Attributes: []AttributeRule{
+				{Name: "CPR", Required: true, Allowed: []string{"CCO", "CCT", "RES", "GOV", "EDU", "ASS", "HOP", "PRT", "TDM", "TRD", "PLT", "LAM", "TRS", "ABO", "INB", "LGR", "OMK", "MAJ"}},
+				{Name: "AgreementVersion", Required: true},
+				{Name: "AgreementValue", Required: true, Allowed: []string{"y"}},
+			},
+			Eligibilities: []Eligibility{EligibilityDotCA},
+		},
+		core.DotES: {
+			DomainKey:   core.DotES,
+			ContactType: TypeEs,
+			Attributes: []AttributeRule{
+				{Name: "es_form_juridica", Required: true, Pattern: regexp.MustCompile(`^[0-9]{1,3}$`)},
+				{Name: "es_tipo_identificacion", Required: true, Allowed: []string{"0", "1", "3"}},
+				{Name: "es_identificacion", Required: true, Pattern: regexp.MustCompile(`^[A-Za-z0-9-]{1,30}$`)},
+			},
+			Eligibilities: []Eligibility{EligibilityDotES},
+		},
+		core.DotRU: {
+			DomainKey:   core.DotRU,
+			ContactType: TypeContact,
+			Attributes: []AttributeRule{
+				{Name: "contract-type", Required: true, Allowed: []string{"PRS", "ORG"}},
+				{Name: "birth-date", Pattern: regexp.MustCompile(`^\d{2}\.\d{2}\.\d{4}$`), RequiredWhen: &AttributeCondition{Name: "contract-type", Value: "PRS"}},
+				{Name: "person-r-name", RequiredWhen: &AttributeCondition{Name: "contract-type", Value: "PRS"}},
+				{Name: "passport", RequiredWhen: &AttributeCondition{Name: "contract-type", Value: "PRS"}},
+				{Name: "org-r", RequiredWhen: &AttributeCondition{Name: "contract-type", Value: "ORG"}},
+				{Name: "code", Pattern: regexp.MustCompile(`^\d{10}$`), RequiredWhen: &AttributeCondition{Name: "contract-type", Value: "ORG"}},
+				{Name: "kpp", Pattern: regexp.MustCompile(`^\d{9}$`)},
+				{Name: "address-r", Required: true},
+			},
+			Eligibilities: []Eligibility{EligibilityDotRU},
+		},
+		core.DotASIA: {
+			DomainKey:   core.DotASIA,
+			ContactType: TypeContact,
+			Attributes: []AttributeRule{
+				{Name: "locality", Required: true, Pattern: regexp.MustCompile(`^[A-Z]{2}$`)},
+				{Name: "legalentitytype", Required: true, Allowed: []string{"naturalPerson", "corporation", "cooperative", "partnership", "government", "politicalParty", "society", "institution", "other"}},
+				{Name: "otherlegalentitytype", RequiredWhen: &AttributeCondition{Name: "legalentitytype", Value: "other"}},
+				{Name: "identform", Required: true, Allowed: []string{"passport", "certificate", "legislation", "societyRegistry", "politicalPartyRegistry", "other"}},
+				{Name: "otheridentform", RequiredWhen: &AttributeCondition{Name: "identform", Value: "other"}},
+				{Name: "identnumber", Required: true},
+			},
+			Eligibilities: []Eligibility{EligibilityDotASIA1, EligibilityDotASIA2},
+		},
+		core.DotEU: {
+			DomainKey:     core.DotEU,
+			ContactType:   TypeEu,
+			Countries:     countriesEU,
+			Eligibilities: []Eligibility{EligibilityDotEU},
+		},
+		core.DotUK: {
+			DomainKey:   core.DotUK,
+			ContactType: TypeUk,
+		},
+		core.DotNYC: {
+			DomainKey:   core.DotNYC,
+			ContactType: TypeNyc,
+			Countries:   []string{"US"},
+			States:      []string{"NY", "New York"},
+		},
+		core.DotCOOP: {
+			DomainKey:   core.DotCOOP,
+			ContactType: TypeCoop,
+			Attributes: []AttributeRule{
+				{Name: "sponsor1", Required: true, Pattern: core.RgxNumber},
+			},
+			Eligibilities: []Eligibility{EligibilityDotCOOP},
+		},
+		core.DotCN: {
+			DomainKey:   core.DotCN,
+			ContactType: TypeCn,
+		},
+		core.DotCO: {
+			DomainKey:   core.DotCO,
+			ContactType: TypeCo,
+		},
+		core.DotDE: {
+			DomainKey:   core.DotDE,
+			ContactType: TypeDe,
+		},
+		core.DotNL: {
+			DomainKey:   core.DotNL,
+			ContactType: TypeNl,
+		},
+	}
+)
+
+func RequirementOf(key core.DomainKey) ContactRequirement {
+	if r, ok := Requirements[key]; ok {
+		return r
+	}
+	return ContactRequirement{DomainKey: key, ContactType: TypeContact}
+}
+
+func (r ContactRequirement) Validate(details *ContactDetail, attributes core.EntityAttributes) error {
+	if details == nil {
+		return errors.New("detail must not nil")
+	}
+
+	if len(details.Type) > 0 && details.Type != r.ContactType {
+		return fmt.Errorf("%s requires contact type %s", r.DomainKey, r.ContactType)
+	}
+
+	if len(r.Countries) > 0 && !containsFold(r.Countries, details.CountryCode) {
+		return fmt.Errorf("%s does not allow contact country %q", r.DomainKey, details.CountryCode)
+	}
+
+	if len(r.States) > 0 && !containsFold(r.States, details.State) {
+		return fmt.Errorf("%s does not allow contact state %q", r.DomainKey, details.State)
+	}
+
+	return r.ValidateAttributes(attributes)
+}
+
+func (r ContactRequirement) ValidateAttributes(attributes core.EntityAttributes) error {
+	get := func(name string) string {
+		if attributes == nil {
+			return ""
+		}
+		return strings.TrimSpace(attributes.Get(name))
+	}
+
+	for _, rule := range r.Attributes {
+		value := get(rule.Name)
+		required := rule.Required
+		if rule.RequiredWhen != nil && get(rule.RequiredWhen.Name) == rule.RequiredWhen.Value {
+			required = true
+		}
+
+		if len(value) <= 0 {
+			if required {
+				return fmt.Errorf("%s requires attribute %q", r.DomainKey, rule.Name)
+			}
+			continue
+		}
+
+		if len(rule.Allowed) > 0 && !contains(rule.Allowed, value) {
+			return fmt.Errorf("invalid value %q for attribute %q, allowed values are %s", value, rule.Name, strings.Join(rule.Allowed, ", "))
+		}
+		if rule.Pattern != nil && !rule.Pattern.MatchString(value) {
+			return fmt.Errorf("invalid format for attribute %q", rule.Name)
+		}
+	}
+
+	return nil
+}
+
+func ValidateForDomainKeys(details *ContactDetail, attributes core.EntityAttributes, domainKeys []core.DomainKey) error {
+	for _, key := range domainKeys {
+		if err := RequirementOf(key).Validate(details, attributes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
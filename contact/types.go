@@ -2,6 +2,7 @@ package contact
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/xpartacvs/go-resellerclub/core"
+	"github.com/xpartacvs/go-resellerclub/general"
 )
 
 type ContactType string
@@ -174,28 +176,28 @@ func (c *ContactCriteria) UrlValues() (url.Values, error) {
 	return urlValues, nil
 }
 
-// func extractSponsorData(c ContactDetail) (*url.Values, error) {
-// 	valueCurrent := reflect.ValueOf(c)
-// 	typeCurrent := reflect.TypeOf(c)
-
-// 	ret := url.Values{}
-// 	for i := 0; i < valueCurrent.NumField(); i++ {
-// 		vFieldCurrent := valueCurrent.Field(i)
-// 		tFieldCurrent := typeCurrent.Field(i)
-// 		tagFieldCurrent := tFieldCurrent.Tag.Get("sponsor")
-// 		if len(tagFieldCurrent) <= 0 || tagFieldCurrent == "-" || vFieldCurrent.Kind() != reflect.String {
-// 			continue
-// 		}
-// 		if vFieldCurrent.IsZero() {
-// 			if !strings.HasSuffix(tagFieldCurrent, ",optional") {
-// 				return nil, errors.New(strings.ToLower(tFieldCurrent.Name) + " must not empty")
-// 			}
-// 			continue
-// 		}
-// 		ret.Add(strings.TrimSuffix(tagFieldCurrent, ",optional"), vFieldCurrent.String())
-// 	}
-// 	return &ret, nil
-// }
+func extractSponsorData(c ContactDetail) (*url.Values, error) {
+	valueCurrent := reflect.ValueOf(c)
+	typeCurrent := reflect.TypeOf(c)
+
+	ret := url.Values{}
+	for i := 0; i < valueCurrent.NumField(); i++ {
+		vFieldCurrent := valueCurrent.Field(i)
+		tFieldCurrent := typeCurrent.Field(i)
+		tagFieldCurrent := tFieldCurrent.Tag.Get("sponsor")
+		if len(tagFieldCurrent) <= 0 || tagFieldCurrent == "-" || vFieldCurrent.Kind() != reflect.String {
+			continue
+		}
+		if vFieldCurrent.IsZero() {
+			if !strings.HasSuffix(tagFieldCurrent, ",optional") {
+				return nil, errors.New(strings.ToLower(tFieldCurrent.Name) + " must not empty")
+			}
+			continue
+		}
+		ret.Add(strings.TrimSuffix(tagFieldCurrent, ",optional"), vFieldCurrent.String())
+	}
+	return &ret, nil
+}
 
 func (c *ContactDetail) UrlValues() (*url.Values, error) {
 	v := validator.New()
@@ -225,3 +227,61 @@ func (c *ContactDetail) UrlValues() (*url.Values, error) {
 
 	return ret, nil
 }
+
+func (c *ContactDetail) mergePrevious(prev *ContactDetail) error {
+	if prev == nil {
+		return errors.New("previous detail must not nil")
+	}
+
+	valueCurrent := reflect.ValueOf(c)
+	typeCurrent := reflect.TypeOf(c)
+	valuePrev := reflect.ValueOf(prev)
+
+	for i := 0; i < valueCurrent.Elem().NumField(); i++ {
+		vFieldCurrent := valueCurrent.Elem().Field(i)
+		tagFieldCurrent := typeCurrent.Elem().Field(i).Tag.Get("query")
+		if len(tagFieldCurrent) <= 0 || tagFieldCurrent == "-" || vFieldCurrent.Kind() != reflect.String {
+			continue
+		}
+		if vFieldCurrent.IsZero() && vFieldCurrent.CanSet() {
+			vFieldCurrent.SetString(valuePrev.Elem().Field(i).String())
+		}
+	}
+
+	return nil
+}
+
+func (c *ContactDetail) NormalizePhones() error {
+	phone, err := general.NormalizePhone(c.PhoneCountryCode, c.Phone, general.CountryISO(c.CountryCode))
+	if err != nil {
+		return err
+	}
+	c.PhoneCountryCode, c.Phone = phone.CallingCode, phone.Subscriber
+
+	if len(strings.TrimSpace(c.Fax)) > 0 {
+		fax, err := general.NormalizePhone(c.FaxCountryCode, c.Fax, general.CountryISO(c.CountryCode))
+		if err != nil {
+			return err
+		}
+		c.FaxCountryCode, c.Fax = fax.CallingCode, fax.Subscriber
+	}
+
+	return nil
+}
+
+func (c *ContactDetail) ResolveState(g general.General) error {
+	if g == nil || len(strings.TrimSpace(c.State)) <= 0 {
+		return nil
+	}
+
+	resolution, err := g.ResolveState(general.CountryISO(c.CountryCode), c.State)
+	if err != nil {
+		return err
+	}
+	if resolution.IsOther() {
+		return fmt.Errorf("state %q is not valid for country %s", c.State, c.CountryCode)
+	}
+
+	c.State = resolution.State
+	return nil
+}
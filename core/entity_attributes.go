@@ -2,8 +2,8 @@ package core
 
 import (
 	"net/url"
+	"sort"
 	"strconv"
-	"sync"
 )
 
 type entityAttributes struct {
@@ -14,6 +14,7 @@ type EntityAttributes interface {
 	Add(key, val string)
 	Get(key string) string
 	Del(key string)
+	Keys() []string
 	UrlValues() url.Values
 	CopyTo(dest *url.Values)
 }
@@ -23,23 +24,10 @@ func (e *entityAttributes) CopyTo(dest *url.Values) {
 		return
 	}
 
-	wg := sync.WaitGroup{}
-	rwMutex := sync.RWMutex{}
-	index := 0
-
-	for key, val := range e.data {
-		wg.Add(1)
-		index++
-		go func(i int, k, v string) {
-			defer wg.Done()
-			rwMutex.Lock()
-			dest.Add("attr-name"+strconv.Itoa(i), k)
-			dest.Add("attr-value"+strconv.Itoa(i), v)
-			rwMutex.Unlock()
-		}(index, key, val)
+	for i, key := range e.Keys() {
+		dest.Add("attr-name"+strconv.Itoa(i+1), key)
+		dest.Add("attr-value"+strconv.Itoa(i+1), e.data[key])
 	}
-
-	wg.Wait()
 }
 
 func (e *entityAttributes) Add(key, val string) {
@@ -54,25 +42,18 @@ func (e *entityAttributes) Del(key string) {
 	delete(e.data, key)
 }
 
-func (e *entityAttributes) UrlValues() url.Values {
-	ret := url.Values{}
-	wg := sync.WaitGroup{}
-	rwMutex := sync.RWMutex{}
-	index := 0
-
-	for key, val := range e.data {
-		wg.Add(1)
-		index++
-		go func(i int, k, v string) {
-			defer wg.Done()
-			rwMutex.Lock()
-			ret.Add("attr-name"+strconv.Itoa(i), k)
-			ret.Add("attr-value"+strconv.Itoa(i), v)
-			rwMutex.Unlock()
-		}(index, key, val)
+func (e *entityAttributes) Keys() []string {
+	keys := make([]string, 0, len(e.data))
+	for key := range e.data {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
+}
 
-	wg.Wait()
+func (e *entityAttributes) UrlValues() url.Values {
+	ret := url.Values{}
+	e.CopyTo(&ret)
 	return ret
 }
 
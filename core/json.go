@@ -72,7 +72,7 @@ func (j JSONTimestamp) ToTime() time.Time {
 
 func (j *JSONUint16) UnmarshalJSON(b []byte) error {
 	s := strings.Trim(string(b), "\"")
-	tValue, err := strconv.ParseInt(s, 10, 16)
+	tValue, err := strconv.ParseUint(s, 10, 16)
 	if err != nil {
 		return err
 	}
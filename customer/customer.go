@@ -274,6 +274,14 @@ func (c *customer) Modify(customerIdOrEmail string, modification CustomerDetail)
 		return nil
 	}
 
+	country := modification.CountryCode
+	if len(country) <= 0 {
+		country = customerBefore.CountryCode
+	}
+	if err := modification.normalizePhones(country); err != nil {
+		return err
+	}
+
 	if err := modification.mergePrevious(customerBefore); err != nil {
 		return nil
 	}
@@ -586,6 +594,13 @@ func (c *customer) ChangePassword(customerId, newPassword string) error {
 }
 
 func (c *customer) SignUp(regForm *SignUpForm) error {
+	if regForm == nil {
+		return errors.New("signup form must not nil")
+	}
+	if err := regForm.NormalizePhones(); err != nil {
+		return err
+	}
+
 	urlValues, err := regForm.UrlValues()
 	if err != nil {
 		return err
@@ -0,0 +1,252 @@
+package customer
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xpartacvs/go-resellerclub/contact"
+	"github.com/xpartacvs/go-resellerclub/core"
+	"github.com/xpartacvs/go-resellerclub/domain"
+	"github.com/xpartacvs/go-resellerclub/general"
+)
+
+type fakeCustomer struct {
+	Customer
+	signups int
+}
+
+func (f *fakeCustomer) SignUp(regForm *SignUpForm) error {
+	f.signups++
+	regForm.CustomerId = "500"
+	return nil
+}
+
+type fakeContact struct {
+	contact.Contact
+	nextId      int
+	added       []*contact.ContactDetail
+	deleted     []string
+	defaults    map[contact.ContactType]string
+	failDefault contact.ContactType
+	failDelete  string
+}
+
+func (f *fakeContact) Add(details *contact.ContactDetail, attributes core.EntityAttributes) error {
+	f.nextId++
+	details.Id = strconv.Itoa(f.nextId)
+	f.added = append(f.added, details)
+	return nil
+}
+
+func (f *fakeContact) Delete(contactId string) (*contact.Action, error) {
+	if contactId == f.failDelete {
+		return &contact.Action{EntityId: contactId, Status: "Failed", StatusDescription: "Contact is in use"}, nil
+	}
+	f.deleted = append(f.deleted, contactId)
+	return &contact.Action{EntityId: contactId}, nil
+}
+
+func (f *fakeContact) SetDefault(customerId, regContactID, adminContactID, techContactID, billContactID string, types []contact.ContactType) error {
+	if types[0] == f.failDefault {
+		return errors.New("cannot set default contact")
+	}
+	f.defaults[types[0]] = regContactID
+	return nil
+}
+
+func (f *fakeContact) Default(customerId string, types []contact.ContactType) (map[string]contact.ContactDetail, error) {
+	id, ok := f.defaults[types[0]]
+	if !ok {
+		return nil, errors.New("no default contacts")
+	}
+	ret := map[string]contact.ContactDetail{}
+	for _, role := range []string{"registrant", "admin", "tech", "billing"} {
+		ret[role] = contact.ContactDetail{Id: id}
+	}
+	return ret, nil
+}
+
+func TestOnboard(t *testing.T) {
+	form := &SignUpForm{
+		Username:         "jane@example.com",
+		Name:             "Jane Doe",
+		Company:          "Example",
+		Address:          "1 Main St",
+		City:             "Berlin",
+		State:            "Berlin",
+		Country:          "DE",
+		Zipcode:          "10115",
+		PhoneCountryCode: "49",
+		Phone:            "301234567",
+	}
+
+	cust := &fakeCustomer{}
+	cont := &fakeContact{defaults: map[contact.ContactType]string{}}
+	result, err := NewOnboarding(cust, cont).Onboard(&OnboardRequest{
+		Form:         form,
+		ContactTypes: []contact.ContactType{contact.TypeContact, contact.TypeEu},
+	})
+	require.NoError(t, err)
+	require.True(t, result.SignedUp)
+	require.Equal(t, "500", result.CustomerId)
+	require.Equal(t, map[contact.ContactType]string{contact.TypeContact: "1", contact.TypeEu: "2"}, result.ContactIds)
+	require.Equal(t, "500", cont.added[1].CustomerId)
+	require.Equal(t, "jane@example.com", cont.added[1].Email)
+	require.Equal(t, result.ContactIds, cont.defaults)
+
+	cont = &fakeContact{defaults: map[contact.ContactType]string{contact.TypeContact: "90"}, failDefault: contact.TypeEu}
+	result, err = NewOnboarding(cust, cont).Onboard(&OnboardRequest{
+		Form:         form,
+		ContactTypes: []contact.ContactType{contact.TypeContact, contact.TypeEu},
+	})
+	require.EqualError(t, err, "cannot set default contact")
+	require.Equal(t, 1, cust.signups)
+	require.Equal(t, []string{"2", "1"}, cont.deleted)
+	require.Empty(t, result.ContactIds)
+	require.Equal(t, "90", cont.defaults[contact.TypeContact])
+
+	cont = &fakeContact{defaults: map[contact.ContactType]string{}, failDefault: contact.TypeEu, failDelete: "1"}
+	result, err = NewOnboarding(cust, cont).Onboard(&OnboardRequest{
+		Form:         form,
+		ContactTypes: []contact.ContactType{contact.TypeContact, contact.TypeEu},
+	})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "no previous default Contact contacts to restore")
+	require.Contains(t, err.Error(), "delete contact 1: contact is in use")
+	require.Equal(t, []string{"2"}, cont.deleted)
+	require.Equal(t, map[contact.ContactType]string{contact.TypeContact: "1"}, result.ContactIds)
+
+	form.Country = "US"
+	cont = &fakeContact{defaults: map[contact.ContactType]string{}}
+	_, err = NewOnboarding(cust, cont).Onboard(&OnboardRequest{
+		Form:         form,
+		ContactTypes: []contact.ContactType{contact.TypeContact, contact.TypeEu},
+	})
+	require.Error(t, err)
+	require.Equal(t, []string{"1"}, cont.deleted)
+}
+
+type fakeGeneral struct {
+	general.General
+}
+
+func (f *fakeGeneral) ResolveState(iso general.CountryISO, state string) (*general.StateResolution, error) {
+	if strings.EqualFold(state, "ny") {
+		return &general.StateResolution{State: "New York", Matched: true}, nil
+	}
+	return &general.StateResolution{State: general.OtherState, OtherState: state}, nil
+}
+
+func TestNormalizePhones(t *testing.T) {
+	form := &SignUpForm{
+		Country:          "LT",
+		PhoneCountryCode: "+370",
+		Phone:            "612 34567",
+		Mobile:           "+370 (612) 34-568",
+		Fax:              "+1 217 555 0100",
+	}
+	require.NoError(t, form.NormalizePhones())
+	require.Equal(t, "370", form.PhoneCountryCode)
+	require.Equal(t, "61234567", form.Phone)
+	require.Equal(t, "370", form.MobileCountryCode)
+	require.Equal(t, "61234568", form.Mobile)
+	require.Equal(t, "1", form.FaxCountryCode)
+	require.Equal(t, "2175550100", form.Fax)
+	require.Empty(t, form.AltPhone)
+
+	detail := &CustomerDetail{CountryCode: "DE", PhoneCountryCode: "49", Phone: "030 1234567"}
+	require.NoError(t, detail.NormalizePhones())
+	require.Equal(t, "49", detail.PhoneCountryCode)
+	require.Equal(t, "301234567", detail.Phone)
+
+	detail = &CustomerDetail{PhoneCountryCode: "383", Phone: "44 123 456"}
+	require.NoError(t, detail.normalizePhones("XK"))
+	require.Equal(t, "383", detail.PhoneCountryCode)
+	require.Equal(t, "44123456", detail.Phone)
+
+	detail = &CustomerDetail{CountryCode: "US", PhoneCountryCode: "1", Phone: "555-0100"}
+	require.Error(t, detail.NormalizePhones())
+}
+
+func TestSignUpFormResolveState(t *testing.T) {
+	form := &SignUpForm{Country: "US", State: "NY"}
+	require.NoError(t, form.ResolveState(&fakeGeneral{}))
+	require.Equal(t, "New York", form.State)
+	require.Empty(t, form.OtherState)
+
+	form.State = "Gotham"
+	require.NoError(t, form.ResolveState(&fakeGeneral{}))
+	require.Equal(t, general.OtherState, form.State)
+	require.Equal(t, "Gotham", form.OtherState)
+}
+
+type fakeExportCustomer struct {
+	Customer
+}
+
+func (f *fakeExportCustomer) Details(customerIdOrEmail string) (*CustomerDetail, error) {
+	return &CustomerDetail{Id: customerIdOrEmail, Name: "Jane Doe", Username: "jane@example.com", Pin: "1234"}, nil
+}
+
+type fakeExportContact struct {
+	contact.Contact
+	criteria []contact.ContactCriteria
+}
+
+func (f *fakeExportContact) Search(criteria contact.ContactCriteria, offset, limit uint16) (*contact.ContactSearchResult, error) {
+	f.criteria = append(f.criteria, criteria)
+	return &contact.ContactSearchResult{TotalMatched: 1, Contacts: []contact.ContactDetail{{Id: "10", Name: "Jane Doe", Email: "jane@example.com"}}}, nil
+}
+
+type fakeExportDomain struct {
+	domain.Domain
+}
+
+func (f *fakeExportDomain) SearchOrderSummaries(criteria domain.OrderCriteria, offset, limit uint16) (*domain.OrderSearchResult, error) {
+	return &domain.OrderSearchResult{TotalMatched: 1, Orders: []domain.OrderSummary{{OrderID: "100", DomainName: "example.com", CurrentStatus: "Active"}}}, nil
+}
+
+func (f *fakeExportDomain) GetRegistrationOrderDetails(orderID string, options []string) (*domain.OrderDetail, error) {
+	detail := &domain.OrderDetail{RegistrantContactID: "10", AdminContactID: "10", TechContactID: "10", BillingContactID: "10", IsPrivacyProtected: true}
+	detail.GDPR.Enabled = true
+	return detail, nil
+}
+
+func TestExport(t *testing.T) {
+	cont := &fakeExportContact{}
+	bundle, err := NewExporter(&fakeExportCustomer{}, cont, &fakeExportDomain{}).Export("500")
+	require.NoError(t, err)
+	require.Equal(t, "500", bundle.Customer.Id)
+	require.Empty(t, bundle.Customer.Pin)
+	require.True(t, cont.criteria[0].IsIncludeInvalid)
+	require.Len(t, bundle.Contacts, 1)
+	require.Len(t, bundle.Orders, 1)
+	require.True(t, bundle.Orders[0].PrivacyProtected)
+	require.True(t, bundle.Orders[0].GDPREnabled)
+	require.False(t, bundle.Orders[0].GDPREligible)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, bundle.WriteJSON(buf))
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.Equal(t, "Jane Doe", decoded["customer"].(map[string]interface{})["Name"])
+	require.Equal(t, "10", decoded["orders"].([]interface{})[0].(map[string]interface{})["RegContactID"])
+	require.NotContains(t, decoded["customer"], "Pin")
+
+	bundle.Customer.Pin = "1234"
+	require.NotContains(t, exportFieldMap(bundle.Customer), "Pin")
+
+	buf.Reset()
+	require.NoError(t, bundle.WriteCSV(buf))
+	records, err := csv.NewReader(buf).ReadAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"section", "id", "field", "value"}, records[0])
+	require.Contains(t, records, []string{"contact", "10", "Email", "jane@example.com"})
+	require.Contains(t, records, []string{"order", "100", "GDPREligible", "false"})
+}
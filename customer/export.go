@@ -0,0 +1,246 @@
+package customer
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xpartacvs/go-resellerclub/contact"
+	"github.com/xpartacvs/go-resellerclub/core"
+	"github.com/xpartacvs/go-resellerclub/domain"
+)
+
+type ExportOrder struct {
+	OrderID          string
+	DomainName       string
+	CurrentStatus    string
+	RegContactID     string
+	AdminContactID   string
+	TechContactID    string
+	BillingContactID string
+	PrivacyAllowed   bool
+	PrivacyProtected bool
+	GDPREnabled      bool
+	GDPREligible     bool
+}
+
+type ExportBundle struct {
+	GeneratedAt time.Time
+	Customer    *CustomerDetail
+	Contacts    []contact.ContactDetail
+	Orders      []ExportOrder
+}
+
+type Exporter interface {
+	Export(customerId string) (*ExportBundle, error)
+}
+
+type exporter struct {
+	customer Customer
+	contact  contact.Contact
+	domain   domain.Domain
+}
+
+const exportPageSize = 100
+
+var (
+	typeTime = reflect.TypeOf(time.Time{})
+
+	exportSecretFields = map[string]bool{
+		"Pin": true,
+	}
+)
+
+func NewExporter(cust Customer, cont contact.Contact, dom domain.Domain) Exporter {
+	return &exporter{
+		customer: cust,
+		contact:  cont,
+		domain:   dom,
+	}
+}
+
+func (x *exporter) Export(customerId string) (*ExportBundle, error) {
+	if !core.RgxNumber.MatchString(customerId) {
+		return nil, core.ErrRcInvalidCredential
+	}
+
+	detail, err := x.customer.Details(customerId)
+	if err != nil {
+		return nil, err
+	}
+	detail.Pin = ""
+
+	bundle := &ExportBundle{
+		GeneratedAt: time.Now().UTC(),
+		Customer:    detail,
+		Contacts:    []contact.ContactDetail{},
+		Orders:      []ExportOrder{},
+	}
+
+	for page := uint16(1); ; page++ {
+		result, err := x.contact.Search(contact.ContactCriteria{CustomerId: customerId, IsIncludeInvalid: true}, page, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Contacts = append(bundle.Contacts, result.Contacts...)
+		if len(result.Contacts) <= 0 || len(bundle.Contacts) >= result.TotalMatched {
+			break
+		}
+	}
+
+	criteria := domain.OrderCriteria{Criteria: core.Criteria{CustomerIDs: []string{customerId}}}
+	for page, seen := uint16(1), 0; ; page++ {
+		result, err := x.domain.SearchOrderSummaries(criteria, page, exportPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, order := range result.Orders {
+			od, err := x.domain.GetRegistrationOrderDetails(order.OrderID, []string{"OrderDetails", "ContactIds"})
+			if err != nil {
+				return nil, err
+			}
+			bundle.Orders = append(bundle.Orders, ExportOrder{
+				OrderID:          order.OrderID,
+				DomainName:       order.DomainName,
+				CurrentStatus:    order.CurrentStatus,
+				RegContactID:     od.RegistrantContactID,
+				AdminContactID:   od.AdminContactID,
+				TechContactID:    od.TechContactID,
+				BillingContactID: od.BillingContactID,
+				PrivacyAllowed:   bool(od.PrivacyProtectedAllowed),
+				PrivacyProtected: bool(od.IsPrivacyProtected),
+				GDPREnabled:      bool(od.GDPR.Enabled),
+				GDPREligible:     bool(od.GDPR.Eligible),
+			})
+		}
+		seen += len(result.Orders)
+		if len(result.Orders) <= 0 || seen >= result.TotalMatched {
+			break
+		}
+	}
+
+	return bundle, nil
+}
+
+func (b *ExportBundle) WriteJSON(w io.Writer) error {
+	if b == nil {
+		return errors.New("bundle must not nil")
+	}
+
+	contacts := []map[string]string{}
+	for i := range b.Contacts {
+		contacts = append(contacts, exportFieldMap(&b.Contacts[i]))
+	}
+	orders := []map[string]string{}
+	for i := range b.Orders {
+		orders = append(orders, exportFieldMap(&b.Orders[i]))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(map[string]interface{}{
+		"generatedAt": b.GeneratedAt.Format(time.RFC3339),
+		"customer":    exportFieldMap(b.Customer),
+		"contacts":    contacts,
+		"orders":      orders,
+	})
+}
+
+func (b *ExportBundle) WriteCSV(w io.Writer) error {
+	if b == nil {
+		return errors.New("bundle must not nil")
+	}
+
+	writer := csv.NewWriter(w)
+	rows := [][]string{{"section", "id", "field", "value"}}
+	rows = append(rows, []string{"bundle", "", "GeneratedAt", b.GeneratedAt.Format(time.RFC3339)})
+
+	if b.Customer != nil {
+		rows = append(rows, exportRows("customer", b.Customer.Id, b.Customer)...)
+	}
+	for i := range b.Contacts {
+		rows = append(rows, exportRows("contact", b.Contacts[i].Id, &b.Contacts[i])...)
+	}
+	for i := range b.Orders {
+		rows = append(rows, exportRows("order", b.Orders[i].OrderID, &b.Orders[i])...)
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+func exportRows(section, id string, v interface{}) [][]string {
+	rows := [][]string{}
+	for _, f := range exportFields(v) {
+		rows = append(rows, []string{section, id, f[0], f[1]})
+	}
+	return rows
+}
+
+func exportFieldMap(v interface{}) map[string]string {
+	ret := map[string]string{}
+	for _, f := range exportFields(v) {
+		ret[f[0]] = f[1]
+	}
+	return ret
+}
+
+func exportFields(v interface{}) [][2]string {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() || (value.Kind() == reflect.Ptr && value.IsNil()) {
+		return nil
+	}
+	return collectExportFields("", reflect.Indirect(value))
+}
+
+func collectExportFields(prefix string, value reflect.Value) [][2]string {
+	fields := [][2]string{}
+	for i := 0; i < value.NumField(); i++ {
+		tField := value.Type().Field(i)
+		if len(tField.PkgPath) > 0 || exportSecretFields[tField.Name] {
+			continue
+		}
+		vField := value.Field(i)
+		name := prefix + tField.Name
+
+		if vField.Kind() == reflect.Struct && !vField.Type().ConvertibleTo(typeTime) {
+			fields = append(fields, collectExportFields(name+".", vField)...)
+			continue
+		}
+		if vField.IsZero() && vField.Kind() != reflect.Bool {
+			continue
+		}
+		fields = append(fields, [2]string{name, exportValue(vField)})
+	}
+	return fields
+}
+
+func exportValue(value reflect.Value) string {
+	switch {
+	case value.Type().ConvertibleTo(typeTime):
+		return value.Convert(typeTime).Interface().(time.Time).UTC().Format(time.RFC3339)
+	case value.Kind() == reflect.Slice:
+		items := []string{}
+		for i := 0; i < value.Len(); i++ {
+			items = append(items, exportValue(value.Index(i)))
+		}
+		return strings.Join(items, ";")
+	case value.Kind() == reflect.Map:
+		keys := value.MapKeys()
+		items := []string{}
+		for _, k := range keys {
+			items = append(items, fmt.Sprintf("%v=%s", k.Interface(), exportValue(value.MapIndex(k))))
+		}
+		sort.Strings(items)
+		return strings.Join(items, ";")
+	}
+	return fmt.Sprintf("%v", value.Interface())
+}
@@ -0,0 +1,189 @@
+package customer
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/xpartacvs/go-resellerclub/contact"
+	"github.com/xpartacvs/go-resellerclub/core"
+)
+
+type OnboardRequest struct {
+	Form         *SignUpForm
+	ContactTypes []contact.ContactType
+	Attributes   map[contact.ContactType]core.EntityAttributes
+}
+
+type OnboardResult struct {
+	CustomerId string
+	SignedUp   bool
+	ContactIds map[contact.ContactType]string
+}
+
+type Onboarding interface {
+	Onboard(req *OnboardRequest) (*OnboardResult, error)
+}
+
+type onboarding struct {
+	customer Customer
+	contact  contact.Contact
+}
+
+func NewOnboarding(cust Customer, cont contact.Contact) Onboarding {
+	return &onboarding{
+		customer: cust,
+		contact:  cont,
+	}
+}
+
+func (f *SignUpForm) ContactDetail(contactType contact.ContactType) *contact.ContactDetail {
+	state := f.State
+	if len(f.OtherState) > 0 {
+		state = f.OtherState
+	}
+	return &contact.ContactDetail{
+		Type:             contactType,
+		CustomerId:       f.CustomerId,
+		Name:             f.Name,
+		Email:            f.Username,
+		Company:          f.Company,
+		Address:          f.Address,
+		AddressLine2:     f.AddressLine2,
+		AddressLine3:     f.AddressLine3,
+		City:             f.City,
+		State:            state,
+		CountryCode:      f.Country,
+		Zipcode:          f.Zipcode,
+		PhoneCountryCode: f.PhoneCountryCode,
+		Phone:            f.Phone,
+		FaxCountryCode:   f.FaxCountryCode,
+		Fax:              f.Fax,
+	}
+}
+
+func (o *onboarding) Onboard(req *OnboardRequest) (*OnboardResult, error) {
+	if req == nil || req.Form == nil {
+		return nil, errors.New("signup form must not nil")
+	}
+
+	types := req.ContactTypes
+	if len(types) <= 0 {
+		types = []contact.ContactType{contact.TypeContact}
+	}
+
+	seen := map[contact.ContactType]bool{}
+	for _, t := range types {
+		if seen[t] {
+			return nil, fmt.Errorf("duplicate contact type %s", t)
+		}
+		seen[t] = true
+	}
+
+	result := &OnboardResult{
+		CustomerId: req.Form.CustomerId,
+		ContactIds: map[contact.ContactType]string{},
+	}
+
+	if len(result.CustomerId) <= 0 {
+		if err := o.customer.SignUp(req.Form); err != nil {
+			return nil, err
+		}
+		result.CustomerId = req.Form.CustomerId
+		result.SignedUp = true
+	}
+
+	created := []contact.ContactType{}
+	applied := []contact.ContactType{}
+	previous := map[contact.ContactType][]string{}
+	rollback := func(cause error) (*OnboardResult, error) {
+		failures := []string{}
+		for i := len(applied) - 1; i >= 0; i-- {
+			t := applied[i]
+			ids, ok := previous[t]
+			if !ok {
+				failures = append(failures, fmt.Sprintf("no previous default %s contacts to restore", t))
+				continue
+			}
+			if err := o.contact.SetDefault(result.CustomerId, ids[0], ids[1], ids[2], ids[3], []contact.ContactType{t}); err != nil {
+				failures = append(failures, fmt.Sprintf("restore default %s contacts: %v", t, err))
+			}
+		}
+
+		remaining := map[contact.ContactType]string{}
+		for i := len(created) - 1; i >= 0; i-- {
+			t := created[i]
+			id := result.ContactIds[t]
+			action, err := o.contact.Delete(id)
+			if err == nil && strings.EqualFold(action.Status, "failed") {
+				err = errors.New(strings.ToLower(action.StatusDescription))
+			}
+			if err != nil {
+				remaining[t] = id
+				failures = append(failures, fmt.Sprintf("delete contact %s: %v", id, err))
+			}
+		}
+		result.ContactIds = remaining
+
+		if len(failures) > 0 {
+			return result, fmt.Errorf("%w (rollback incomplete: %s)", cause, strings.Join(failures, "; "))
+		}
+		return result, cause
+	}
+
+	for _, t := range types {
+		details := req.Form.ContactDetail(t)
+		attributes := req.Attributes[t]
+		if key, ok := contactDomainKey(t); ok {
+			if err := contact.RequirementOf(key).Validate(details, attributes); err != nil {
+				return rollback(err)
+			}
+		}
+		if err := o.contact.Add(details, attributes); err != nil {
+			return rollback(err)
+		}
+		created = append(created, t)
+		result.ContactIds[t] = details.Id
+	}
+
+	for _, t := range types {
+		if ids, ok := o.defaultContactIds(result.CustomerId, t); ok {
+			previous[t] = ids
+		}
+		id := result.ContactIds[t]
+		if err := o.contact.SetDefault(result.CustomerId, id, id, id, id, []contact.ContactType{t}); err != nil {
+			return rollback(err)
+		}
+		applied = append(applied, t)
+	}
+
+	return result, nil
+}
+
+func (o *onboarding) defaultContactIds(customerId string, t contact.ContactType) ([]string, bool) {
+	defaults, err := o.contact.Default(customerId, []contact.ContactType{t})
+	if err != nil {
+		return nil, false
+	}
+	ids := []string{}
+	for _, role := range []string{"registrant", "admin", "tech", "billing"} {
+		c, ok := defaults[role]
+		if !ok || !core.RgxNumber.MatchString(c.Id) {
+			return nil, false
+		}
+		ids = append(ids, c.Id)
+	}
+	return ids, true
+}
+
+func contactDomainKey(t contact.ContactType) (core.DomainKey, bool) {
+	if t == contact.TypeContact {
+		return "", false
+	}
+	for key, r := range contact.Requirements {
+		if r.ContactType == t {
+			return key, true
+		}
+	}
+	return "", false
+}
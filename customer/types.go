@@ -10,6 +10,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/xpartacvs/go-resellerclub/core"
+	"github.com/xpartacvs/go-resellerclub/general"
 )
 
 type loginToken struct {
@@ -37,13 +38,13 @@ type SignUpForm struct {
 	Country               string `validate:"required,iso3166_1_alpha2" query:"country"`
 	Zipcode               string `validate:"required" query:"zipcode"`
 	LanguageCode          string `validate:"required" query:"lang-pref"`
-	PhoneCountryCode      string `validate:"required,len=2" query:"phone-cc"`
+	PhoneCountryCode      string `validate:"required,min=1,max=3,number" query:"phone-cc"`
 	Phone                 string `validate:"required,number" query:"phone"`
-	AltPhoneCountryCode   string `validate:"omitempty,len=2" query:"alt-phone-cc,omitempty"`
+	AltPhoneCountryCode   string `validate:"omitempty,min=1,max=3,number" query:"alt-phone-cc,omitempty"`
 	AltPhone              string `validate:"omitempty,number" query:"alt-phone,omitempty"`
-	FaxCountryCode        string `validate:"omitempty,len=2" query:"fax-cc,omitempty"`
+	FaxCountryCode        string `validate:"omitempty,min=1,max=3,number" query:"fax-cc,omitempty"`
 	Fax                   string `validate:"omitempty,number" query:"fax,omitempty"`
-	MobileCountryCode     string `validate:"omitempty,len=2" query:"mobile-cc,omitempty"`
+	MobileCountryCode     string `validate:"omitempty,min=1,max=3,number" query:"mobile-cc,omitempty"`
 	Mobile                string `validate:"omitempty,number" query:"mobile,omitempty"`
 	VatID                 string `validate:"omitempty" query:"vat-id,omitempty"`
 	SmsConcent            bool   `validate:"omitempty" query:"sms-consent,omitempty"`
@@ -60,13 +61,13 @@ type CustomerDetail struct {
 	Name                    string          `json:"name,omitempty" validate:"omitempty" query:"name"`
 	Company                 string          `json:"company,omitempty" validate:"omitempty" query:"company"`
 	Email                   string          `json:"useremail,omitempty" validate:"-" query:"-"`
-	PhoneCountryCode        string          `json:"telnocc,omitempty" validate:"omitempty,len=2,number" query:"phone-cc"`
+	PhoneCountryCode        string          `json:"telnocc,omitempty" validate:"omitempty,min=1,max=3,number" query:"phone-cc"`
 	Phone                   string          `json:"telno,omitempty" validate:"omitempty,number" query:"phone"`
-	AltPhoneCountryCode     string          `json:"-" validate:"omitempty,len=2,number" query:"alt-phone-cc,omitempty"`
+	AltPhoneCountryCode     string          `json:"-" validate:"omitempty,min=1,max=3,number" query:"alt-phone-cc,omitempty"`
 	AltPhone                string          `json:"-" validate:"omitempty,number" query:"alt-phone,omitempty"`
-	MobileCountryCode       string          `json:"mobilenocc,omitempty" validate:"omitempty,len=2,number" query:"mobile-cc,omitempty"`
+	MobileCountryCode       string          `json:"mobilenocc,omitempty" validate:"omitempty,min=1,max=3,number" query:"mobile-cc,omitempty"`
 	Mobile                  string          `json:"mobileno,omitempty" validate:"omitempty,number" query:"mobile,omitempty"`
-	FaxCountryCode          string          `json:"-" validate:"omitempty,len=2" query:"faxnocc,omitempty"`
+	FaxCountryCode          string          `json:"-" validate:"omitempty,min=1,max=3,number" query:"faxnocc,omitempty"`
 	Fax                     string          `json:"-" validate:"omitempty,number" query:"faxno,omitempty"`
 	Address                 string          `json:"address1,omitempty" validate:"omitempty" query:"address-line-1"`
 	AddressLine2            string          `json:"address2,omitempty" validate:"omitempty" query:"address-line-2,omitempty"`
@@ -344,3 +345,66 @@ func matchPasswordWithPattern(password string, withRangeOfLength bool) bool {
 	rgxSymbol := regexp.MustCompile(`[\~\*\!\@\$\#\%\_\+\.\?\:\,\{\}]`)
 	return rgxAlphaLower.MatchString(password) && rgxAlphaUpper.MatchString(password) && rgxSymbol.MatchString(password)
 }
+
+func (r *SignUpForm) NormalizePhones() error {
+	return normalizePhones(r.Country,
+		[2]*string{&r.PhoneCountryCode, &r.Phone},
+		[2]*string{&r.AltPhoneCountryCode, &r.AltPhone},
+		[2]*string{&r.FaxCountryCode, &r.Fax},
+		[2]*string{&r.MobileCountryCode, &r.Mobile},
+	)
+}
+
+func (c *CustomerDetail) NormalizePhones() error {
+	return c.normalizePhones(c.CountryCode)
+}
+
+func (c *CustomerDetail) normalizePhones(country string) error {
+	return normalizePhones(country,
+		[2]*string{&c.PhoneCountryCode, &c.Phone},
+		[2]*string{&c.AltPhoneCountryCode, &c.AltPhone},
+		[2]*string{&c.FaxCountryCode, &c.Fax},
+		[2]*string{&c.MobileCountryCode, &c.Mobile},
+	)
+}
+
+func normalizePhones(country string, pairs ...[2]*string) error {
+	for _, pair := range pairs {
+		if len(strings.TrimSpace(*pair[1])) <= 0 {
+			continue
+		}
+		phone, err := general.NormalizePhone(*pair[0], *pair[1], general.CountryISO(country))
+		if err != nil {
+			return err
+		}
+		*pair[0], *pair[1] = phone.CallingCode, phone.Subscriber
+	}
+	return nil
+}
+
+func (r *SignUpForm) ResolveState(g general.General) error {
+	return resolveState(g, r.Country, &r.State, &r.OtherState)
+}
+
+func (c *CustomerDetail) ResolveState(g general.General) error {
+	return resolveState(g, c.CountryCode, &c.State, &c.OtherState)
+}
+
+func resolveState(g general.General, country string, state, otherState *string) error {
+	if g == nil || len(strings.TrimSpace(*state)) <= 0 {
+		return nil
+	}
+
+	input := *state
+	if *state == general.OtherState && len(*otherState) > 0 {
+		input = *otherState
+	}
+
+	resolution, err := g.ResolveState(general.CountryISO(country), input)
+	if err != nil {
+		return err
+	}
+
+	*state, *otherState = resolution.State, resolution.OtherState
+	return nil
+}
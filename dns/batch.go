@@ -0,0 +1,202 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/xpartacvs/go-resellerclub/core"
+)
+
+type batch struct {
+	dns         DNS
+	parallelism int
+	mutex       sync.Mutex
+	operations  []Operation
+}
+
+type Batch interface {
+	Add(domainName string, record *Record) Batch
+	Modify(domainName string, record *Record, newValue string) Batch
+	Delete(domainName string, record *Record) Batch
+	Operations() []Operation
+	Len() int
+	Execute() []OperationResult
+}
+
+func NewBatch(d DNS, parallelism int) Batch {
+	if parallelism <= 0 {
+		parallelism = 1
+	}
+	return &batch{
+		dns:         d,
+		parallelism: parallelism,
+	}
+}
+
+func (b *batch) Add(domainName string, record *Record) Batch {
+	return b.queue(Operation{Kind: OperationAdd, DomainName: domainName, Record: record})
+}
+
+func (b *batch) Modify(domainName string, record *Record, newValue string) Batch {
+	return b.queue(Operation{Kind: OperationModify, DomainName: domainName, Record: record, NewValue: newValue})
+}
+
+func (b *batch) Delete(domainName string, record *Record) Batch {
+	return b.queue(Operation{Kind: OperationDelete, DomainName: domainName, Record: record})
+}
+
+func (b *batch) queue(op Operation) Batch {
+	b.mutex.Lock()
+	b.operations = append(b.operations, op)
+	b.mutex.Unlock()
+	return b
+}
+
+func (b *batch) Operations() []Operation {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	ret := make([]Operation, len(b.operations))
+	copy(ret, b.operations)
+	return ret
+}
+
+func (b *batch) Len() int {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	return len(b.operations)
+}
+
+func (b *batch) Execute() []OperationResult {
+	operations := b.Operations()
+	results := make([]OperationResult, len(operations))
+
+	wg := sync.WaitGroup{}
+	semaphore := make(chan struct{}, b.parallelism)
+	for i, op := range operations {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(idx int, o Operation) {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+			results[idx] = executeOperation(b.dns, o)
+		}(i, op)
+	}
+	wg.Wait()
+
+	return results
+}
+
+func executeOperation(d DNS, op Operation) OperationResult {
+	result := OperationResult{Operation: op}
+
+	if err := op.Record.Validate(); err != nil {
+		result.Err = err
+		return result
+	}
+
+	var resp *StdResponse
+	var err error
+	switch op.Kind {
+	case OperationAdd:
+		resp, err = addRecord(d, op.DomainName, op.Record)
+	case OperationModify:
+		modified := *op.Record
+		modified.Value = op.NewValue
+		if err := modified.Validate(); err != nil {
+			result.Err = err
+			return result
+		}
+		resp, err = modifyRecord(d, op.DomainName, op.Record, op.NewValue)
+	case OperationDelete:
+		resp, err = deleteRecord(d, op.DomainName, op.Record)
+	default:
+		err = fmt.Errorf("unsupported operation %q", op.Kind)
+	}
+
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	result.Message = resp.Msg
+	if !strings.EqualFold(resp.Status, "success") {
+		result.Err = core.ErrRcOperationFailed
+		if len(resp.Msg) > 0 {
+			result.Err = errors.New(strings.ToLower(resp.Msg))
+		}
+		return result
+	}
+
+	result.Success = true
+	return result
+}
+
+func addRecord(d DNS, domainName string, r *Record) (*StdResponse, error) {
+	switch r.Type {
+	case RecordA:
+		return d.AddingIPv4AddressRecord(domainName, r.Value, r.Host, r.TTL)
+	case RecordAAAA:
+		return d.AddingIPv6AddressRecord(domainName, r.Value, r.Host, r.TTL)
+	case RecordCNAME:
+		return d.AddingCNAMERecord(domainName, r.Value, r.Host, r.TTL)
+	case RecordMX:
+		return d.AddingMXRecord(domainName, r.Value, r.Host, r.TTL, r.Priority)
+	case RecordNS:
+		return d.AddingNSRecord(domainName, r.Value, r.Host, r.TTL)
+	case RecordTXT:
+		return d.AddingTXTRecord(domainName, r.Value, r.Host, r.TTL)
+	case RecordSRV:
+		return d.AddingSRVRecord(domainName, r.Value, r.Host, r.TTL, r.Priority, r.Port, r.Weight)
+	case RecordCAA:
+		return d.AddingCAARecord(domainName, r.Value, r.Host, r.TTL, r.Flag, r.Tag)
+	}
+	return nil, fmt.Errorf("unsupported record type %q", r.Type)
+}
+
+func modifyRecord(d DNS, domainName string, r *Record, newValue string) (*StdResponse, error) {
+	switch r.Type {
+	case RecordA:
+		return d.ModifyingIPv4AddressRecord(domainName, r.Host, r.Value, newValue, r.TTL)
+	case RecordAAAA:
+		return d.ModifyingIPv6AddressRecord(domainName, r.Host, r.Value, newValue, r.TTL)
+	case RecordCNAME:
+		return d.ModifyingCNAMERecord(domainName, r.Host, r.Value, newValue, r.TTL)
+	case RecordMX:
+		return d.ModifyingMXRecord(domainName, r.Host, r.Value, newValue, r.TTL, r.Priority)
+	case RecordNS:
+		return d.ModifyingNSRecord(domainName, r.Host, r.Value, newValue, r.TTL)
+	case RecordTXT:
+		return d.ModifyingTXTRecord(domainName, r.Host, r.Value, newValue, r.TTL)
+	case RecordSRV:
+		return d.ModifyingSRVRecord(domainName, r.Host, r.Value, newValue, r.TTL, r.Priority, r.Port, r.Weight)
+	case RecordCAA:
+		return d.ModifyingCAARecord(domainName, r.Host, r.Value, newValue, r.TTL, r.Flag, r.Tag)
+	}
+	return nil, fmt.Errorf("unsupported record type %q", r.Type)
+}
+
+func deleteRecord(d DNS, domainName string, r *Record) (*StdResponse, error) {
+	switch r.Type {
+	case RecordA:
+		return d.DeletingIPv4AddressRecord(domainName, r.Host, r.Value)
+	case RecordAAAA:
+		return d.DeletingIPv6AddressRecord(domainName, r.Host, r.Value)
+	case RecordCNAME:
+		return d.DeletingCNAMERecord(domainName, r.Host, r.Value)
+	case RecordMX:
+		return d.DeletingMXRecord(domainName, r.Host, r.Value)
+	case RecordNS:
+		return d.DeletingNSRecord(domainName, r.Host, r.Value)
+	case RecordTXT:
+		return d.DeletingTXTRecord(domainName, r.Host, r.Value)
+	case RecordSRV:
+		return d.DeletingSRVRecord(domainName, r.Host, r.Value, r.Port, r.Weight)
+	case RecordCAA:
+		return d.DeletingCAARecord(domainName, r.Host, r.Value, r.Flag, r.Tag)
+	}
+	return nil, fmt.Errorf("unsupported record type %q", r.Type)
+}
@@ -0,0 +1,61 @@
+package dns
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchExecute(t *testing.T) {
+	c := &fakeCore{
+		delay: 10 * time.Millisecond,
+		responses: map[string]string{
+			"manage/add-ipv4-record 192.0.2.3": `{"status":"Failed","msg":"Record already exists"}`,
+		},
+	}
+	b := NewBatch(New(c), 2)
+
+	values := []string{"192.0.2.1", "192.0.2.2", "192.0.2.3", "192.0.2.4", "192.0.2.5"}
+	for _, v := range values {
+		r, err := NewARecord("www", v, DefaultTTL)
+		require.NoError(t, err)
+		b.Add("example.com", r)
+	}
+	b.Delete("example.com", &Record{Type: RecordA, Host: "www", Value: "not-an-ip"})
+	require.Equal(t, 6, b.Len())
+
+	results := b.Execute()
+	require.Len(t, results, 6)
+	for i, v := range values {
+		require.Equal(t, v, results[i].Operation.Record.Value)
+	}
+
+	require.True(t, results[0].Success)
+	require.NoError(t, results[0].Err)
+	require.False(t, results[2].Success)
+	require.EqualError(t, results[2].Err, "record already exists")
+	require.True(t, results[4].Success)
+	require.False(t, results[5].Success)
+	require.Error(t, results[5].Err)
+
+	require.Len(t, c.calls, 5)
+	require.Equal(t, 2, c.maxActive)
+}
+
+func TestBatchExecuteSequential(t *testing.T) {
+	c := &fakeCore{delay: time.Millisecond}
+	b := NewBatch(New(c), 0)
+
+	r, err := NewTXTRecord("@", "v=spf1 -all", DefaultTTL)
+	require.NoError(t, err)
+	b.Add("example.com", r).Modify("example.com", r, "v=spf1 mx -all").Delete("example.com", r)
+
+	results := b.Execute()
+	require.Len(t, results, 3)
+	for _, res := range results {
+		require.True(t, res.Success)
+	}
+	require.Equal(t, 1, c.maxActive)
+	require.Equal(t, []string{"manage/add-txt-record", "manage/update-txt-record", "manage/delete-txt-record"}, c.apiNames())
+}
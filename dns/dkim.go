@@ -0,0 +1,217 @@
+package dns
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type DKIM struct {
+	KeyType        string
+	PublicKey      string
+	HashAlgorithms []string
+	ServiceTypes   []string
+	Flags          []string
+	Notes          string
+}
+
+const (
+	DKIMKeyRSA     = "rsa"
+	DKIMKeyEd25519 = "ed25519"
+
+	dkimVersion       = "v=DKIM1"
+	dkimMinRSAKeyBits = 1024
+)
+
+func NewDKIM(keyType, publicKey string) *DKIM {
+	return &DKIM{
+		KeyType:   keyType,
+		PublicKey: publicKey,
+	}
+}
+
+func DKIMHost(selector string) string {
+	return selector + "._domainkey"
+}
+
+func ParseDKIM(value string) (*DKIM, error) {
+	tags, err := parseTagList(JoinTXT(value))
+	if err != nil {
+		return nil, err
+	}
+
+	if v, ok := tags["v"]; ok && v != "DKIM1" {
+		return nil, fmt.Errorf("unsupported dkim version %q", v)
+	}
+
+	p, ok := tags["p"]
+	if !ok {
+		return nil, errors.New("dkim record must have p tag")
+	}
+
+	dkim := &DKIM{
+		KeyType:   DKIMKeyRSA,
+		PublicKey: strings.Join(strings.Fields(p), ""),
+		Notes:     tags["n"],
+	}
+	if k, ok := tags["k"]; ok {
+		dkim.KeyType = strings.ToLower(k)
+	}
+	dkim.HashAlgorithms = splitTagValue(tags["h"])
+	dkim.ServiceTypes = splitTagValue(tags["s"])
+	dkim.Flags = splitTagValue(tags["t"])
+
+	if err := dkim.Validate(); err != nil {
+		return nil, err
+	}
+	return dkim, nil
+}
+
+func (k *DKIM) String() string {
+	parts := []string{dkimVersion}
+	if len(k.KeyType) > 0 && k.KeyType != DKIMKeyRSA {
+		parts = append(parts, "k="+k.KeyType)
+	}
+	if len(k.HashAlgorithms) > 0 {
+		parts = append(parts, "h="+strings.Join(k.HashAlgorithms, ":"))
+	}
+	if len(k.ServiceTypes) > 0 {
+		parts = append(parts, "s="+strings.Join(k.ServiceTypes, ":"))
+	}
+	if len(k.Flags) > 0 {
+		parts = append(parts, "t="+strings.Join(k.Flags, ":"))
+	}
+	if len(k.Notes) > 0 {
+		parts = append(parts, "n="+k.Notes)
+	}
+	parts = append(parts, "p="+k.PublicKey)
+	return strings.Join(parts, "; ")
+}
+
+func (k *DKIM) IsRevoked() bool {
+	return len(k.PublicKey) <= 0
+}
+
+func (k *DKIM) KeyBits() (int, error) {
+	if k.IsRevoked() {
+		return 0, nil
+	}
+
+	der, err := base64.StdEncoding.DecodeString(k.PublicKey)
+	if err != nil {
+		return 0, fmt.Errorf("invalid dkim public key encoding: %w", err)
+	}
+
+	switch k.KeyType {
+	case DKIMKeyRSA, "":
+		pub, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			if pub, err = x509.ParsePKCS1PublicKey(der); err != nil {
+				return 0, fmt.Errorf("invalid dkim rsa public key: %w", err)
+			}
+		}
+		rsaKey, ok := pub.(*rsa.PublicKey)
+		if !ok {
+			return 0, errors.New("dkim public key is not rsa")
+		}
+		return rsaKey.N.BitLen(), nil
+	case DKIMKeyEd25519:
+		if len(der) != ed25519.PublicKeySize {
+			return 0, fmt.Errorf("dkim ed25519 key must be %d bytes", ed25519.PublicKeySize)
+		}
+		return ed25519.PublicKeySize * 8, nil
+	}
+
+	return 0, fmt.Errorf("unsupported dkim key type %q", k.KeyType)
+}
+
+func (k *DKIM) Validate() error {
+	bits, err := k.KeyBits()
+	if err != nil {
+		return err
+	}
+	if (k.KeyType == DKIMKeyRSA || k.KeyType == "") && !k.IsRevoked() && bits < dkimMinRSAKeyBits {
+		return fmt.Errorf("dkim rsa key is %d bits, minimum is %d", bits, dkimMinRSAKeyBits)
+	}
+
+	for _, h := range k.HashAlgorithms {
+		if h != "sha1" && h != "sha256" {
+			return fmt.Errorf("invalid dkim hash algorithm %q", h)
+		}
+	}
+	for _, s := range k.ServiceTypes {
+		if s != "*" && s != "email" {
+			return fmt.Errorf("invalid dkim service type %q", s)
+		}
+	}
+	for _, f := range k.Flags {
+		if f != "y" && f != "s" {
+			return fmt.Errorf("invalid dkim flag %q", f)
+		}
+	}
+
+	return nil
+}
+
+func FindDKIM(d DNS, domainName, selector string) (*DKIM, *DNSRecord, error) {
+	record, err := findTXTRecord(d, domainName, DKIMHost(selector), "")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dkim, err := ParseDKIM(record.Value)
+	if err != nil {
+		return nil, record, err
+	}
+	return dkim, record, nil
+}
+
+func SaveDKIM(d DNS, domainName, selector string, dkim *DKIM, ttl int) (*StdResponse, error) {
+	if dkim == nil {
+		return nil, errors.New("dkim must not nil")
+	}
+	if len(selector) <= 0 {
+		return nil, errors.New("dkim selector must not empty")
+	}
+	if err := dkim.Validate(); err != nil {
+		return nil, err
+	}
+	return saveTXTRecord(d, domainName, DKIMHost(selector), "", dkim.String(), ttl)
+}
+
+func parseTagList(value string) (map[string]string, error) {
+	tags := map[string]string{}
+	for _, part := range strings.Split(value, ";") {
+		part = strings.TrimSpace(part)
+		if len(part) <= 0 {
+			continue
+		}
+		idx := strings.Index(part, "=")
+		if idx <= 0 {
+			return nil, fmt.Errorf("invalid tag %q", part)
+		}
+		name := strings.ToLower(strings.TrimSpace(part[:idx]))
+		if _, ok := tags[name]; ok {
+			return nil, fmt.Errorf("duplicate tag %q", name)
+		}
+		tags[name] = strings.TrimSpace(part[idx+1:])
+	}
+	return tags, nil
+}
+
+func splitTagValue(value string) []string {
+	if len(value) <= 0 {
+		return nil
+	}
+	ret := []string{}
+	for _, v := range strings.Split(value, ":") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
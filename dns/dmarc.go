@@ -0,0 +1,204 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+type DMARCPolicy string
+type DMARCAlignment string
+
+type DMARC struct {
+	Policy          DMARCPolicy
+	SubdomainPolicy DMARCPolicy
+	Percent         *int
+	AggregateURIs   []string
+	ForensicURIs    []string
+	DKIMAlignment   DMARCAlignment
+	SPFAlignment    DMARCAlignment
+	FailureOptions  string
+	ReportInterval  int
+}
+
+const (
+	DMARCNone       DMARCPolicy = "none"
+	DMARCQuarantine DMARCPolicy = "quarantine"
+	DMARCReject     DMARCPolicy = "reject"
+
+	DMARCRelaxed DMARCAlignment = "r"
+	DMARCStrict  DMARCAlignment = "s"
+
+	DMARCHost = "_dmarc"
+
+	dmarcVersion = "v=DMARC1"
+)
+
+var rgxDMARCFailureOptions = regexp.MustCompile(`^[01ds](:[01ds])*$`)
+
+func NewDMARC(policy DMARCPolicy) *DMARC {
+	return &DMARC{Policy: policy}
+}
+
+func ParseDMARC(value string) (*DMARC, error) {
+	value = strings.TrimSpace(JoinTXT(value))
+	if !strings.HasPrefix(value, dmarcVersion) {
+		return nil, errors.New("dmarc record must start with " + dmarcVersion)
+	}
+
+	tags, err := parseTagList(value)
+	if err != nil {
+		return nil, err
+	}
+
+	dmarc := &DMARC{}
+	for name, v := range tags {
+		switch name {
+		case "v":
+		case "p":
+			dmarc.Policy = DMARCPolicy(strings.ToLower(v))
+		case "sp":
+			dmarc.SubdomainPolicy = DMARCPolicy(strings.ToLower(v))
+		case "pct":
+			pct, err := strconv.Atoi(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid dmarc pct %q", v)
+			}
+			dmarc.Percent = &pct
+		case "rua":
+			dmarc.AggregateURIs = splitDMARCURIs(v)
+		case "ruf":
+			dmarc.ForensicURIs = splitDMARCURIs(v)
+		case "adkim":
+			dmarc.DKIMAlignment = DMARCAlignment(strings.ToLower(v))
+		case "aspf":
+			dmarc.SPFAlignment = DMARCAlignment(strings.ToLower(v))
+		case "fo":
+			dmarc.FailureOptions = v
+		case "ri":
+			if dmarc.ReportInterval, err = strconv.Atoi(v); err != nil {
+				return nil, fmt.Errorf("invalid dmarc ri %q", v)
+			}
+		case "rf":
+			if !strings.EqualFold(v, "afrf") {
+				return nil, fmt.Errorf("invalid dmarc rf %q", v)
+			}
+		default:
+			// RFC 7489 section 6.3: unknown tags must be ignored
+		}
+	}
+
+	if err := dmarc.Validate(); err != nil {
+		return nil, err
+	}
+	return dmarc, nil
+}
+
+func (m *DMARC) String() string {
+	parts := []string{dmarcVersion, "p=" + string(m.Policy)}
+	if len(m.SubdomainPolicy) > 0 {
+		parts = append(parts, "sp="+string(m.SubdomainPolicy))
+	}
+	if m.Percent != nil && *m.Percent != 100 {
+		parts = append(parts, "pct="+strconv.Itoa(*m.Percent))
+	}
+	if len(m.AggregateURIs) > 0 {
+		parts = append(parts, "rua="+strings.Join(m.AggregateURIs, ","))
+	}
+	if len(m.ForensicURIs) > 0 {
+		parts = append(parts, "ruf="+strings.Join(m.ForensicURIs, ","))
+	}
+	if len(m.DKIMAlignment) > 0 {
+		parts = append(parts, "adkim="+string(m.DKIMAlignment))
+	}
+	if len(m.SPFAlignment) > 0 {
+		parts = append(parts, "aspf="+string(m.SPFAlignment))
+	}
+	if len(m.FailureOptions) > 0 {
+		parts = append(parts, "fo="+m.FailureOptions)
+	}
+	if m.ReportInterval > 0 {
+		parts = append(parts, "ri="+strconv.Itoa(m.ReportInterval))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func (m *DMARC) Validate() error {
+	if !validDMARCPolicy(m.Policy) {
+		return fmt.Errorf("invalid dmarc policy %q", m.Policy)
+	}
+	if len(m.SubdomainPolicy) > 0 && !validDMARCPolicy(m.SubdomainPolicy) {
+		return fmt.Errorf("invalid dmarc subdomain policy %q", m.SubdomainPolicy)
+	}
+	if m.Percent != nil && (*m.Percent < 0 || *m.Percent > 100) {
+		return fmt.Errorf("dmarc pct must be in range of 0 to 100")
+	}
+	for _, uri := range append(append([]string{}, m.AggregateURIs...), m.ForensicURIs...) {
+		if err := validateDMARCURI(uri); err != nil {
+			return err
+		}
+	}
+	for _, a := range []DMARCAlignment{m.DKIMAlignment, m.SPFAlignment} {
+		if len(a) > 0 && a != DMARCRelaxed && a != DMARCStrict {
+			return fmt.Errorf("invalid dmarc alignment %q", a)
+		}
+	}
+	if len(m.FailureOptions) > 0 && !rgxDMARCFailureOptions.MatchString(m.FailureOptions) {
+		return fmt.Errorf("invalid dmarc fo %q", m.FailureOptions)
+	}
+	if m.ReportInterval < 0 {
+		return errors.New("dmarc ri must not negative")
+	}
+	return nil
+}
+
+func FindDMARC(d DNS, domainName string) (*DMARC, *DNSRecord, error) {
+	record, err := findTXTRecord(d, domainName, DMARCHost, dmarcVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dmarc, err := ParseDMARC(record.Value)
+	if err != nil {
+		return nil, record, err
+	}
+	return dmarc, record, nil
+}
+
+func SaveDMARC(d DNS, domainName string, dmarc *DMARC, ttl int) (*StdResponse, error) {
+	if dmarc == nil {
+		return nil, errors.New("dmarc must not nil")
+	}
+	if err := dmarc.Validate(); err != nil {
+		return nil, err
+	}
+	return saveTXTRecord(d, domainName, DMARCHost, dmarcVersion, dmarc.String(), ttl)
+}
+
+func validDMARCPolicy(p DMARCPolicy) bool {
+	return p == DMARCNone || p == DMARCQuarantine || p == DMARCReject
+}
+
+func splitDMARCURIs(value string) []string {
+	ret := []string{}
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); len(v) > 0 {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func validateDMARCURI(uri string) error {
+	if idx := strings.LastIndex(uri, "!"); idx > 0 {
+		uri = uri[:idx]
+	}
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "mailto" || len(u.Opaque) <= 0 {
+		return fmt.Errorf("invalid dmarc report uri %q", uri)
+	}
+	return nil
+}
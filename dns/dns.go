@@ -22,6 +22,7 @@ type DNS interface {
 	AddingNSRecord(domainName, value, host string, ttl int) (*StdResponse, error)
 	AddingTXTRecord(domainName, value, host string, ttl int) (*StdResponse, error)
 	AddingSRVRecord(domainName, value, host string, ttl, priority, port, weight int) (*StdResponse, error)
+	AddingCAARecord(domainName, value, host string, ttl int, flag uint8, tag CAATag) (*StdResponse, error)
 	ModifyingIPv4AddressRecord(domainName, host, currentValue, newValue string, ttl int) (*StdResponse, error)
 	ModifyingIPv6AddressRecord(domainName, host, currentValue, newValue string, ttl int) (*StdResponse, error)
 	ModifyingCNAMERecord(domainName, host, currentValue, newValue string, ttl int) (*StdResponse, error)
@@ -29,6 +30,7 @@ type DNS interface {
 	ModifyingNSRecord(domainName, host, currentValue, newValue string, ttl int) (*StdResponse, error)
 	ModifyingTXTRecord(domainName, host, currentValue, newValue string, ttl int) (*StdResponse, error)
 	ModifyingSRVRecord(domainName, host, currentValue, newValue string, ttl, priority, port, weight int) (*StdResponse, error)
+	ModifyingCAARecord(domainName, host, currentValue, newValue string, ttl int, flag uint8, tag CAATag) (*StdResponse, error)
 	ModifyingSOARecord(domainName, responsiblePerson string, refresh, retry, expire, ttl int) (*StdResponse, error)
 	SearchingDNSRecords(domainName string, typeRecord RecordType, noOfRecords, pageNo int, host, value string) (*SearchingDNSRecords, error)
 	DeletingDNSRecord(host, value string) (*StdResponse, error)
@@ -39,6 +41,7 @@ type DNS interface {
 	DeletingNSRecord(domainName, host, value string) (*StdResponse, error)
 	DeletingTXTRecord(domainName, host, value string) (*StdResponse, error)
 	DeletingSRVRecord(domainName, host, value string, port, weight int) (*StdResponse, error)
+	DeletingCAARecord(domainName, host, value string, flag uint8, tag CAATag) (*StdResponse, error)
 }
 
 func New(c core.Core) DNS {
@@ -270,7 +273,7 @@ func (d *dns) AddingTXTRecord(domainName, value, host string, ttl int) (*StdResp
 	data.Add("host", host)
 	data.Add("ttl", strconv.Itoa(ttl))
 
-	resp, err := d.core.CallApi(http.MethodPost, "dns", "manage/manage/add-ns-record", data)
+	resp, err := d.core.CallApi(http.MethodPost, "dns", "manage/add-txt-record", data)
 	if err != nil {
 		return nil, err
 	}
@@ -338,6 +341,44 @@ func (d *dns) AddingSRVRecord(domainName, value, host string, ttl, priority, por
 	return &result, nil
 }
 
+func (d *dns) AddingCAARecord(domainName, value, host string, ttl int, flag uint8, tag CAATag) (*StdResponse, error) {
+	data := make(url.Values)
+	data.Add("domain-name", domainName)
+	data.Add("value", value)
+	data.Add("host", host)
+	data.Add("ttl", strconv.Itoa(ttl))
+	data.Add("flag", strconv.Itoa(int(flag)))
+	data.Add("tag", string(tag))
+
+	resp, err := d.core.CallApi(http.MethodPost, "dns", "manage/add-caa-record", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	var result StdResponse
+	err = json.Unmarshal(bytesResp, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (d *dns) ModifyingIPv4AddressRecord(domainName, host, currentValue, newValue string, ttl int) (*StdResponse, error) {
 	data := make(url.Values)
 	data.Add("domain-name", domainName)
@@ -601,6 +642,45 @@ func (d *dns) ModifyingSRVRecord(domainName, host, currentValue, newValue string
 	return &result, nil
 }
 
+func (d *dns) ModifyingCAARecord(domainName, host, currentValue, newValue string, ttl int, flag uint8, tag CAATag) (*StdResponse, error) {
+	data := make(url.Values)
+	data.Add("domain-name", domainName)
+	data.Add("host", host)
+	data.Add("current-value", currentValue)
+	data.Add("new-value", newValue)
+	data.Add("ttl", strconv.Itoa(ttl))
+	data.Add("flag", strconv.Itoa(int(flag)))
+	data.Add("tag", string(tag))
+
+	resp, err := d.core.CallApi(http.MethodPost, "dns", "manage/update-caa-record", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	var result StdResponse
+	err = json.Unmarshal(bytesResp, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (d *dns) ModifyingSOARecord(domainName, responsiblePerson string, refresh, retry, expire, ttl int) (*StdResponse, error) {
 	data := make(url.Values)
 	data.Add("domain-name", domainName)
@@ -987,3 +1067,40 @@ func (d *dns) DeletingSRVRecord(domainName, host, value string, port, weight int
 
 	return &result, nil
 }
+
+func (d *dns) DeletingCAARecord(domainName, host, value string, flag uint8, tag CAATag) (*StdResponse, error) {
+	data := make(url.Values)
+	data.Add("domain-name", domainName)
+	data.Add("host", host)
+	data.Add("value", value)
+	data.Add("flag", strconv.Itoa(int(flag)))
+	data.Add("tag", string(tag))
+
+	resp, err := d.core.CallApi(http.MethodPost, "dns", "manage/delete-caa-record", data)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	var result StdResponse
+	err = json.Unmarshal(bytesResp, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
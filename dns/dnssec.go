@@ -0,0 +1,173 @@
+package dns
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+)
+
+type DNSKEY struct {
+	Flags     uint16
+	Protocol  uint8
+	Algorithm uint8
+	PublicKey []byte
+}
+
+type DS struct {
+	KeyTag     uint16
+	Algorithm  uint8
+	DigestType uint8
+	Digest     string
+}
+
+const (
+	DigestSHA1   uint8 = 1
+	DigestSHA256 uint8 = 2
+	DigestSHA384 uint8 = 4
+)
+
+func ParseDNSKEY(value string) (*DNSKEY, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("invalid dnskey %q", value)
+	}
+
+	flags, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dnskey flags %q", fields[0])
+	}
+	protocol, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dnskey protocol %q", fields[1])
+	}
+	algorithm, err := strconv.ParseUint(fields[2], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dnskey algorithm %q", fields[2])
+	}
+	publicKey, err := base64.StdEncoding.DecodeString(strings.Join(fields[3:], ""))
+	if err != nil {
+		return nil, fmt.Errorf("invalid dnskey public key: %w", err)
+	}
+
+	return &DNSKEY{
+		Flags:     uint16(flags),
+		Protocol:  uint8(protocol),
+		Algorithm: uint8(algorithm),
+		PublicKey: publicKey,
+	}, nil
+}
+
+func ParseDS(value string) (*DS, error) {
+	fields := strings.Fields(value)
+	if len(fields) < 4 {
+		return nil, fmt.Errorf("invalid ds %q", value)
+	}
+
+	keyTag, err := strconv.ParseUint(fields[0], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ds key tag %q", fields[0])
+	}
+	algorithm, err := strconv.ParseUint(fields[1], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ds algorithm %q", fields[1])
+	}
+	digestType, err := strconv.ParseUint(fields[2], 10, 8)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ds digest type %q", fields[2])
+	}
+
+	return &DS{
+		KeyTag:     uint16(keyTag),
+		Algorithm:  uint8(algorithm),
+		DigestType: uint8(digestType),
+		Digest:     strings.ToUpper(strings.Join(fields[3:], "")),
+	}, nil
+}
+
+func (k *DNSKEY) rdata() []byte {
+	data := make([]byte, 4, 4+len(k.PublicKey))
+	binary.BigEndian.PutUint16(data, k.Flags)
+	data[2] = k.Protocol
+	data[3] = k.Algorithm
+	return append(data, k.PublicKey...)
+}
+
+func (k *DNSKEY) KeyTag() uint16 {
+	var ac uint32
+	for i, b := range k.rdata() {
+		if i&1 == 0 {
+			ac += uint32(b) << 8
+		} else {
+			ac += uint32(b)
+		}
+	}
+	ac += ac >> 16 & 0xFFFF
+	return uint16(ac & 0xFFFF)
+}
+
+func (k *DNSKEY) IsSecureEntryPoint() bool {
+	return k.Flags&1 == 1
+}
+
+func (k *DNSKEY) ToDS(ownerName string, digestType uint8) (*DS, error) {
+	var h hash.Hash
+	switch digestType {
+	case DigestSHA1:
+		h = sha1.New()
+	case DigestSHA256:
+		h = sha256.New()
+	case DigestSHA384:
+		h = sha512.New384()
+	default:
+		return nil, fmt.Errorf("unsupported ds digest type %d", digestType)
+	}
+
+	owner, err := wireName(ownerName)
+	if err != nil {
+		return nil, err
+	}
+	h.Write(owner)
+	h.Write(k.rdata())
+
+	return &DS{
+		KeyTag:     k.KeyTag(),
+		Algorithm:  k.Algorithm,
+		DigestType: digestType,
+		Digest:     strings.ToUpper(hex.EncodeToString(h.Sum(nil))),
+	}, nil
+}
+
+func (ds *DS) String() string {
+	return fmt.Sprintf("%d %d %d %s", ds.KeyTag, ds.Algorithm, ds.DigestType, ds.Digest)
+}
+
+func (ds *DS) Matches(other *DS) bool {
+	return other != nil &&
+		ds.KeyTag == other.KeyTag &&
+		ds.Algorithm == other.Algorithm &&
+		ds.DigestType == other.DigestType &&
+		strings.EqualFold(ds.Digest, other.Digest)
+}
+
+func wireName(name string) ([]byte, error) {
+	name = canonicalName(name)
+	wire := []byte{}
+	if len(name) > 0 {
+		for _, label := range strings.Split(name, ".") {
+			if len(label) <= 0 || len(label) > 63 {
+				return nil, errors.New("invalid domain name " + strconv.Quote(name))
+			}
+			wire = append(wire, byte(len(label)))
+			wire = append(wire, label...)
+		}
+	}
+	return append(wire, 0), nil
+}
@@ -0,0 +1,154 @@
+package dns
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTXTZone struct {
+	DNS
+	records  []*DNSRecord
+	added    []string
+	modified [][2]string
+}
+
+func (f *fakeTXTZone) SearchingDNSRecords(domainName string, typeRecord RecordType, noOfRecords, pageNo int, host, value string) (*SearchingDNSRecords, error) {
+	if pageNo > 1 {
+		return &SearchingDNSRecords{Recsindb: "0"}, nil
+	}
+	return &SearchingDNSRecords{Recsindb: "1", Records: f.records}, nil
+}
+
+func (f *fakeTXTZone) AddingTXTRecord(domainName, value, host string, ttl int) (*StdResponse, error) {
+	f.added = append(f.added, value)
+	return &StdResponse{Status: "Success"}, nil
+}
+
+func (f *fakeTXTZone) ModifyingTXTRecord(domainName, host, currentValue, newValue string, ttl int) (*StdResponse, error) {
+	f.modified = append(f.modified, [2]string{currentValue, newValue})
+	return &StdResponse{Status: "Success"}, nil
+}
+
+func TestParseSPF(t *testing.T) {
+	spf, err := ParseSPF("v=spf1 ip4:192.0.2.0/24 include:_spf.google.com mx -all")
+	require.NoError(t, err)
+	require.Equal(t, 2, spf.LookupCount())
+	require.Equal(t, "v=spf1 ip4:192.0.2.0/24 include:_spf.google.com mx -all", spf.String())
+
+	spf.Include("spf.protection.outlook.com")
+	require.Equal(t, "v=spf1 ip4:192.0.2.0/24 include:_spf.google.com mx include:spf.protection.outlook.com -all", spf.String())
+
+	spf, err = ParseSPF("v=spf1 mx foo=bar.example.com -all")
+	require.NoError(t, err)
+	require.Equal(t, "v=spf1 mx -all", spf.String())
+
+	_, err = ParseSPF("v=spf1 -all mx")
+	require.Error(t, err)
+
+	_, err = ParseSPF("v=spf1 ip4:2001:db8::1 -all")
+	require.Error(t, err)
+
+	tooMany := NewSPF()
+	for i := 0; i < 11; i++ {
+		tooMany.Include("spf" + string(rune('a'+i)) + ".example.com")
+	}
+	require.Error(t, tooMany.All(SPFFail).Validate())
+}
+
+func TestParseDKIM(t *testing.T) {
+	edKey, _, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	dkim, err := ParseDKIM("v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(edKey))
+	require.NoError(t, err)
+	require.Equal(t, DKIMKeyEd25519, dkim.KeyType)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&rsaKey.PublicKey)
+	require.NoError(t, err)
+	dkim = NewDKIM(DKIMKeyRSA, base64.StdEncoding.EncodeToString(der))
+	bits, err := dkim.KeyBits()
+	require.NoError(t, err)
+	require.Equal(t, 2048, bits)
+	require.NoError(t, dkim.Validate())
+
+	weak := &rsa.PublicKey{N: new(big.Int).Lsh(big.NewInt(1), 511), E: 65537}
+	der, err = x509.MarshalPKIXPublicKey(weak)
+	require.NoError(t, err)
+	require.Error(t, NewDKIM(DKIMKeyRSA, base64.StdEncoding.EncodeToString(der)).Validate())
+
+	_, err = ParseDKIM("v=DKIM1; k=rsa")
+	require.Error(t, err)
+}
+
+func TestParseDMARC(t *testing.T) {
+	dmarc, err := ParseDMARC("v=DMARC1; p=quarantine; pct=50; rua=mailto:dmarc@example.com; adkim=s")
+	require.NoError(t, err)
+	require.Equal(t, DMARCQuarantine, dmarc.Policy)
+	require.Equal(t, 50, *dmarc.Percent)
+	require.Equal(t, "v=DMARC1; p=quarantine; pct=50; rua=mailto:dmarc@example.com; adkim=s", dmarc.String())
+
+	dmarc, err = ParseDMARC("v=DMARC1; p=reject; pct=0")
+	require.NoError(t, err)
+	require.Equal(t, 0, *dmarc.Percent)
+	require.Equal(t, "v=DMARC1; p=reject; pct=0", dmarc.String())
+
+	dmarc, err = ParseDMARC("v=DMARC1; p=reject; pct=100")
+	require.NoError(t, err)
+	require.Equal(t, "v=DMARC1; p=reject", dmarc.String())
+
+	dmarc, err = ParseDMARC("v=DMARC1; p=none")
+	require.NoError(t, err)
+	require.Nil(t, dmarc.Percent)
+
+	dmarc, err = ParseDMARC("v=DMARC1; p=none; foo=bar; rua=mailto:dmarc@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "v=DMARC1; p=none; rua=mailto:dmarc@example.com", dmarc.String())
+
+	_, err = ParseDMARC("v=DMARC1; p=none; pct=101")
+	require.Error(t, err)
+
+	_, err = ParseDMARC("v=DMARC1; p=block")
+	require.Error(t, err)
+
+	_, err = ParseDMARC("v=DMARC1; p=none; rua=https://example.com")
+	require.Error(t, err)
+}
+
+func TestTXTChunking(t *testing.T) {
+	value := strings.Repeat("a", 300)
+	require.Len(t, SplitTXT(value), 2)
+	require.Equal(t, value, JoinTXT(FormatTXT(value)))
+	require.Equal(t, "v=spf1 -all", FormatTXT("v=spf1 -all"))
+}
+
+func TestSaveSPF(t *testing.T) {
+	zone := &fakeTXTZone{records: []*DNSRecord{
+		{Type: "TXT", Host: "example.com", Value: "v=spf1 mx -all"},
+		{Type: "TXT", Host: "example.com", Value: "google-site-verification=abc"},
+	}}
+
+	spf, record, err := FindSPF(zone, "example.com")
+	require.NoError(t, err)
+	require.Equal(t, "v=spf1 mx -all", record.Value)
+
+	_, err = SaveSPF(zone, "example.com", spf.Include("_spf.google.com"), 0)
+	require.NoError(t, err)
+	require.Empty(t, zone.added)
+	require.Equal(t, [][2]string{{"v=spf1 mx -all", "v=spf1 mx include:_spf.google.com -all"}}, zone.modified)
+
+	_, _, err = FindDMARC(zone, "example.com")
+	require.Equal(t, ErrRecordNotFound, err)
+
+	_, err = SaveDMARC(zone, "example.com", NewDMARC(DMARCReject), 0)
+	require.NoError(t, err)
+	require.Equal(t, []string{"v=DMARC1; p=reject"}, zone.added)
+}
@@ -0,0 +1,161 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+)
+
+type ServerStatus struct {
+	Server     string
+	Propagated bool
+	Values     []string
+	Err        error
+}
+
+type PropagationResult struct {
+	Record     *Record
+	Propagated bool
+	Servers    []ServerStatus
+}
+
+type Verifier interface {
+	Check(ctx context.Context, domainName string, record *Record) (*PropagationResult, error)
+	Wait(ctx context.Context, domainName string, record *Record) (*PropagationResult, error)
+}
+
+type verifier struct {
+	resolver    Resolver
+	nameServers []string
+	interval    time.Duration
+	timeout     time.Duration
+}
+
+const (
+	defaultPropagationInterval = 10 * time.Second
+	defaultPropagationTimeout  = 10 * time.Minute
+)
+
+var (
+	DefaultNameServers = []string{
+		"dns1.parkpage.foundationapi.com",
+		"dns2.parkpage.foundationapi.com",
+		"dns3.parkpage.foundationapi.com",
+		"dns4.parkpage.foundationapi.com",
+	}
+
+	ErrPropagationTimeout = errors.New("record not propagated before timeout")
+)
+
+func NewVerifier(r Resolver, nameServers []string, interval, timeout time.Duration) Verifier {
+	if r == nil {
+		r = NewResolver(0)
+	}
+	if len(nameServers) <= 0 {
+		nameServers = DefaultNameServers
+	}
+	if interval <= 0 {
+		interval = defaultPropagationInterval
+	}
+	if timeout <= 0 {
+		timeout = defaultPropagationTimeout
+	}
+	return &verifier{
+		resolver:    r,
+		nameServers: nameServers,
+		interval:    interval,
+		timeout:     timeout,
+	}
+}
+
+func (v *verifier) Check(ctx context.Context, domainName string, record *Record) (*PropagationResult, error) {
+	if err := record.Validate(); err != nil {
+		return nil, err
+	}
+
+	name := fqdn(domainName, record.Host)
+	result := &PropagationResult{
+		Record:  record,
+		Servers: make([]ServerStatus, len(v.nameServers)),
+	}
+
+	wg := sync.WaitGroup{}
+	for i, server := range v.nameServers {
+		wg.Add(1)
+		go func(i int, server string) {
+			defer wg.Done()
+			result.Servers[i] = v.checkServer(ctx, server, name, record)
+		}(i, server)
+	}
+	wg.Wait()
+
+	result.Propagated = true
+	for _, s := range result.Servers {
+		if !s.Propagated {
+			result.Propagated = false
+			break
+		}
+	}
+	return result, nil
+}
+
+func (v *verifier) Wait(ctx context.Context, domainName string, record *Record) (*PropagationResult, error) {
+	deadline := time.NewTimer(v.timeout)
+	defer deadline.Stop()
+
+	ticker := time.NewTicker(v.interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := v.Check(ctx, domainName, record)
+		if err != nil {
+			return nil, err
+		}
+		if result.Propagated {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-deadline.C:
+			return result, ErrPropagationTimeout
+		case <-ticker.C:
+		}
+	}
+}
+
+func (v *verifier) checkServer(ctx context.Context, server, name string, record *Record) ServerStatus {
+	status := ServerStatus{Server: server, Values: []string{}}
+
+	resp, err := v.resolver.Query(ctx, server, name, record.Type)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+
+	for _, a := range resp.Answers {
+		if a.Type != record.Type || a.Name != name {
+			continue
+		}
+		status.Values = append(status.Values, a.Value)
+		if a.Matches(record) {
+			status.Propagated = true
+		}
+	}
+	return status
+}
+
+func fqdn(domainName, host string) string {
+	domainName = canonicalName(domainName)
+	host = canonicalName(host)
+	switch {
+	case len(host) <= 0 || host == "@":
+		return domainName
+	case host == domainName || strings.HasSuffix(host, "."+domainName):
+		return host
+	}
+	return host + "." + domainName
+}
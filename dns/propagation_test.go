@@ -0,0 +1,127 @@
+package dns
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func startStubServer(t *testing.T, answers map[string][4]byte) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buffer := make([]byte, 4096)
+		for {
+			n, addr, err := conn.ReadFrom(buffer)
+			if err != nil {
+				return
+			}
+
+			var query dnsmessage.Message
+			if err := query.Unpack(buffer[:n]); err != nil || len(query.Questions) <= 0 {
+				continue
+			}
+			q := query.Questions[0]
+
+			reply := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: query.Header.ID, Response: true, Authoritative: true},
+				Questions: []dnsmessage.Question{q},
+			}
+			if ip, ok := answers[strings.ToLower(q.Name.String())]; ok && q.Type == dnsmessage.TypeA {
+				reply.Answers = append(reply.Answers, dnsmessage.Resource{
+					Header: dnsmessage.ResourceHeader{Name: q.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 300},
+					Body:   &dnsmessage.AResource{A: ip},
+				})
+			} else {
+				reply.Header.RCode = dnsmessage.RCodeNameError
+			}
+
+			packed, err := reply.Pack()
+			if err != nil {
+				continue
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String()
+}
+
+func TestResolverQuery(t *testing.T) {
+	server := startStubServer(t, map[string][4]byte{"www.example.com.": {192, 0, 2, 10}})
+
+	resp, err := NewResolver(time.Second).Query(context.Background(), server, "WWW.example.com", RecordA)
+	require.NoError(t, err)
+	require.True(t, resp.Authoritative)
+	require.Equal(t, []Answer{{Name: "www.example.com", Type: RecordA, TTL: 300, Value: "192.0.2.10"}}, resp.Answers)
+
+	resp, err = NewResolver(time.Second).Query(context.Background(), server, "missing.example.com", RecordA)
+	require.NoError(t, err)
+	require.Equal(t, RCodeNameError, resp.RCode)
+	require.Empty(t, resp.Answers)
+}
+
+func TestResolverQueryIgnoresMismatchedID(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		buffer := make([]byte, 4096)
+		n, addr, err := conn.ReadFrom(buffer)
+		if err != nil {
+			return
+		}
+		var query dnsmessage.Message
+		if err := query.Unpack(buffer[:n]); err != nil {
+			return
+		}
+		for _, id := range []uint16{query.Header.ID + 1, query.Header.ID} {
+			reply := dnsmessage.Message{
+				Header:    dnsmessage.Header{ID: id, Response: true, RCode: dnsmessage.RCodeRefused},
+				Questions: query.Questions,
+			}
+			if id == query.Header.ID {
+				reply.Header.RCode = dnsmessage.RCodeNameError
+			}
+			packed, err := reply.Pack()
+			if err != nil {
+				return
+			}
+			conn.WriteTo(packed, addr)
+		}
+	}()
+
+	resp, err := NewResolver(time.Second).Query(context.Background(), conn.LocalAddr().String(), "missing.example.com", RecordA)
+	require.NoError(t, err)
+	require.Equal(t, RCodeNameError, resp.RCode)
+}
+
+func TestVerifierWait(t *testing.T) {
+	live := startStubServer(t, map[string][4]byte{"www.example.com.": {192, 0, 2, 10}})
+	stale := startStubServer(t, map[string][4]byte{"www.example.com.": {192, 0, 2, 99}})
+
+	record, err := NewARecord("www", "192.0.2.10", DefaultTTL)
+	require.NoError(t, err)
+
+	result, err := NewVerifier(NewResolver(time.Second), []string{live}, 10*time.Millisecond, time.Second).
+		Wait(context.Background(), "example.com", record)
+	require.NoError(t, err)
+	require.True(t, result.Propagated)
+	require.Equal(t, []string{"192.0.2.10"}, result.Servers[0].Values)
+
+	result, err = NewVerifier(NewResolver(time.Second), []string{live, stale}, 10*time.Millisecond, 100*time.Millisecond).
+		Wait(context.Background(), "example.com", record)
+	require.Equal(t, ErrPropagationTimeout, err)
+	require.False(t, result.Propagated)
+	require.True(t, result.Servers[0].Propagated)
+	require.False(t, result.Servers[1].Propagated)
+	require.Equal(t, []string{"192.0.2.99"}, result.Servers[1].Values)
+}
@@ -0,0 +1,222 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var (
+	rgxRecordHost = regexp.MustCompile(`^(@|\*|(\*\.)?[A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9_])?(\.[A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9_])?)*)?$`)
+	rgxHostname   = regexp.MustCompile(`^([A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9])?\.)*[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.?$`)
+	rgxCAAIssuer  = regexp.MustCompile(`^\s*(;|([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)+[A-Za-z]{2,63}\s*(;.*)?)$`)
+	rgxCAAValue   = regexp.MustCompile(`^(\d+)\s+(issue|issuewild|iodef)\s+"?([^"]*)"?$`)
+)
+
+func NewARecord(host, ip string, ttl int) (*Record, error) {
+	return newRecord(&Record{Type: RecordA, Host: host, Value: ip, TTL: ttl})
+}
+
+func NewAAAARecord(host, ip string, ttl int) (*Record, error) {
+	return newRecord(&Record{Type: RecordAAAA, Host: host, Value: ip, TTL: ttl})
+}
+
+func NewCNAMERecord(host, target string, ttl int) (*Record, error) {
+	return newRecord(&Record{Type: RecordCNAME, Host: host, Value: target, TTL: ttl})
+}
+
+func NewMXRecord(host, exchange string, ttl, priority int) (*Record, error) {
+	return newRecord(&Record{Type: RecordMX, Host: host, Value: exchange, TTL: ttl, Priority: priority})
+}
+
+func NewNSRecord(host, nameServer string, ttl int) (*Record, error) {
+	return newRecord(&Record{Type: RecordNS, Host: host, Value: nameServer, TTL: ttl})
+}
+
+func NewTXTRecord(host, text string, ttl int) (*Record, error) {
+	return newRecord(&Record{Type: RecordTXT, Host: host, Value: text, TTL: ttl})
+}
+
+func NewSRVRecord(host, target string, ttl, priority, port, weight int) (*Record, error) {
+	return newRecord(&Record{Type: RecordSRV, Host: host, Value: target, TTL: ttl, Priority: priority, Port: port, Weight: weight})
+}
+
+func NewCAARecord(host string, flag uint8, tag CAATag, value string, ttl int) (*Record, error) {
+	return newRecord(&Record{Type: RecordCAA, Host: host, Value: value, TTL: ttl, Flag: flag, Tag: tag})
+}
+
+func RelativeHost(domainName, host string) string {
+	return normalizeHost(domainName, host)
+}
+
+func FindRecords(d DNS, domainName string, typeRecord RecordType, host string) ([]*Record, error) {
+	records, err := searchAllRecords(d, domainName, typeRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	host = normalizeHost(domainName, host)
+	found := []*Record{}
+	for _, r := range records {
+		record, err := r.ToRecord(domainName)
+		if err != nil {
+			return nil, err
+		}
+		if record.Host == host {
+			found = append(found, record)
+		}
+	}
+	return found, nil
+}
+
+func (r *DNSRecord) ToRecord(domainName string) (*Record, error) {
+	record := &Record{
+		Type:  RecordType(strings.ToUpper(r.Type)),
+		Host:  normalizeHost(domainName, r.Host),
+		Value: r.Value,
+	}
+
+	var err error
+	if len(r.TimeToLive) > 0 {
+		if record.TTL, err = strconv.Atoi(r.TimeToLive); err != nil {
+			return nil, fmt.Errorf("invalid ttl %q", r.TimeToLive)
+		}
+	}
+	if len(r.Priority) > 0 {
+		if record.Priority, err = strconv.Atoi(r.Priority); err != nil {
+			return nil, fmt.Errorf("invalid priority %q", r.Priority)
+		}
+	}
+	if len(r.Port) > 0 {
+		if record.Port, err = strconv.Atoi(r.Port); err != nil {
+			return nil, fmt.Errorf("invalid port %q", r.Port)
+		}
+	}
+	if len(r.Weight) > 0 {
+		if record.Weight, err = strconv.Atoi(r.Weight); err != nil {
+			return nil, fmt.Errorf("invalid weight %q", r.Weight)
+		}
+	}
+
+	if record.Type == RecordCAA {
+		if m := rgxCAAValue.FindStringSubmatch(r.Value); m != nil {
+			flag, err := strconv.ParseUint(m[1], 10, 8)
+			if err != nil {
+				return nil, fmt.Errorf("invalid caa flag %q", m[1])
+			}
+			record.Flag = uint8(flag)
+			record.Tag = CAATag(m[2])
+			record.Value = m[3]
+		}
+	}
+
+	return record, nil
+}
+
+func newRecord(r *Record) (*Record, error) {
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *Record) Validate() error {
+	if r == nil {
+		return errors.New("record must not nil")
+	}
+
+	if err := validator.New().Struct(r); err != nil {
+		return err
+	}
+
+	if !rgxRecordHost.MatchString(r.Host) {
+		return fmt.Errorf("invalid host %q for %s record", r.Host, r.Type)
+	}
+
+	switch r.Type {
+	case RecordA:
+		ip := net.ParseIP(r.Value)
+		if ip == nil || ip.To4() == nil {
+			return fmt.Errorf("invalid ipv4 address %q", r.Value)
+		}
+	case RecordAAAA:
+		ip := net.ParseIP(r.Value)
+		if ip == nil || ip.To4() != nil {
+			return fmt.Errorf("invalid ipv6 address %q", r.Value)
+		}
+	case RecordCNAME, RecordMX, RecordNS, RecordSRV:
+		if !rgxHostname.MatchString(r.Value) {
+			return fmt.Errorf("invalid hostname %q for %s record", r.Value, r.Type)
+		}
+	case RecordTXT:
+		if len(strings.TrimSpace(r.Value)) <= 0 {
+			return errors.New("txt value must not empty")
+		}
+	case RecordCAA:
+		return r.validateCAA()
+	case RecordSOA:
+		return r.validateSOA()
+	default:
+		return fmt.Errorf("unsupported record type %q", r.Type)
+	}
+
+	return nil
+}
+
+func (r *Record) validateSOA() error {
+	fields := strings.Fields(r.Value)
+	if len(fields) != 7 {
+		return fmt.Errorf("invalid soa value %q", r.Value)
+	}
+	for _, name := range fields[:2] {
+		if !rgxHostname.MatchString(name) {
+			return fmt.Errorf("invalid hostname %q for SOA record", name)
+		}
+	}
+	for _, n := range fields[2:] {
+		if _, err := strconv.ParseUint(n, 10, 32); err != nil {
+			return fmt.Errorf("invalid soa value %q", r.Value)
+		}
+	}
+	return nil
+}
+
+func (r *Record) validateCAA() error {
+	if r.Flag != CAAFlagNone && r.Flag != CAAFlagCritical {
+		return fmt.Errorf("invalid caa flag %d", r.Flag)
+	}
+
+	switch r.Tag {
+	case CAAIssue, CAAIssueWild:
+		if !rgxCAAIssuer.MatchString(r.Value) {
+			return fmt.Errorf("invalid caa issuer %q", r.Value)
+		}
+	case CAAIodef:
+		u, err := url.Parse(r.Value)
+		if err != nil {
+			return err
+		}
+		switch u.Scheme {
+		case "mailto":
+			if len(u.Opaque) <= 0 {
+				return fmt.Errorf("invalid caa iodef %q", r.Value)
+			}
+		case "http", "https":
+			if len(u.Host) <= 0 {
+				return fmt.Errorf("invalid caa iodef %q", r.Value)
+			}
+		default:
+			return fmt.Errorf("invalid caa iodef %q", r.Value)
+		}
+	default:
+		return fmt.Errorf("invalid caa tag %q", r.Tag)
+	}
+
+	return nil
+}
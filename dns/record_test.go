@@ -0,0 +1,114 @@
+package dns
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCall struct {
+	apiName string
+	data    url.Values
+}
+
+type fakeCore struct {
+	mutex     sync.Mutex
+	calls     []fakeCall
+	responses map[string]string
+	delay     time.Duration
+	active    int
+	maxActive int
+}
+
+func (f *fakeCore) CallApi(method, namespace, apiName string, data url.Values) (*http.Response, error) {
+	f.mutex.Lock()
+	f.calls = append(f.calls, fakeCall{apiName: apiName, data: data})
+	f.active++
+	if f.active > f.maxActive {
+		f.maxActive = f.active
+	}
+	body, ok := f.responses[apiName+" "+data.Get("value")]
+	if !ok {
+		body, ok = f.responses[apiName]
+	}
+	if !ok {
+		body = `{"status":"Success","msg":"ok"}`
+	}
+	f.mutex.Unlock()
+
+	time.Sleep(f.delay)
+
+	f.mutex.Lock()
+	f.active--
+	f.mutex.Unlock()
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (f *fakeCore) IsProduction() bool {
+	return false
+}
+
+func (f *fakeCore) apiNames() []string {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	names := []string{}
+	for _, c := range f.calls {
+		names = append(names, c.apiName)
+	}
+	return names
+}
+
+func TestRecordValidate(t *testing.T) {
+	_, err := NewCAARecord("@", CAAFlagNone, CAAIssue, "letsencrypt.org", DefaultTTL)
+	require.NoError(t, err)
+	_, err = NewCAARecord("@", CAAFlagCritical, CAAIssueWild, ";", DefaultTTL)
+	require.NoError(t, err)
+	_, err = NewCAARecord("@", CAAFlagNone, CAAIodef, "mailto:security@example.com", DefaultTTL)
+	require.NoError(t, err)
+	_, err = NewCAARecord("@", CAAFlagNone, CAAIodef, "https://example.com/caa", DefaultTTL)
+	require.NoError(t, err)
+
+	_, err = NewCAARecord("@", 1, CAAIssue, "letsencrypt.org", DefaultTTL)
+	require.Error(t, err)
+	_, err = NewCAARecord("@", CAAFlagNone, "policy", "letsencrypt.org", DefaultTTL)
+	require.Error(t, err)
+	_, err = NewCAARecord("@", CAAFlagNone, CAAIssue, "not an issuer", DefaultTTL)
+	require.Error(t, err)
+	_, err = NewCAARecord("@", CAAFlagNone, CAAIodef, "ftp://example.com", DefaultTTL)
+	require.Error(t, err)
+
+	soa := &Record{Type: RecordSOA, Host: "@", Value: "dns1.example.com hostmaster.example.com 2021010101 7200 7200 172800 38400"}
+	require.NoError(t, soa.Validate())
+	soa.Value = "dns1.example.com hostmaster.example.com 2021010101"
+	require.Error(t, soa.Validate())
+
+	_, err = NewARecord("www", "2001:db8::1", DefaultTTL)
+	require.Error(t, err)
+	_, err = NewAAAARecord("www", "192.0.2.1", DefaultTTL)
+	require.Error(t, err)
+}
+
+func TestCAARecordEndpoints(t *testing.T) {
+	c := &fakeCore{}
+	d := New(c)
+
+	_, err := d.AddingCAARecord("example.com", "letsencrypt.org", "@", DefaultTTL, CAAFlagNone, CAAIssue)
+	require.NoError(t, err)
+	_, err = d.ModifyingCAARecord("example.com", "@", "letsencrypt.org", "pki.goog", DefaultTTL, CAAFlagNone, CAAIssue)
+	require.NoError(t, err)
+	_, err = d.DeletingCAARecord("example.com", "@", "pki.goog", CAAFlagNone, CAAIssue)
+	require.NoError(t, err)
+	_, err = d.AddingTXTRecord("example.com", "v=spf1 -all", "@", DefaultTTL)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"manage/add-caa-record", "manage/update-caa-record", "manage/delete-caa-record", "manage/add-txt-record"}, c.apiNames())
+	require.Equal(t, "issue", c.calls[0].data.Get("tag"))
+	require.Equal(t, "0", c.calls[0].data.Get("flag"))
+	require.Equal(t, "pki.goog", c.calls[1].data.Get("new-value"))
+}
@@ -0,0 +1,336 @@
+package dns
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+type Answer struct {
+	Name     string
+	Type     RecordType
+	TTL      uint32
+	Value    string
+	Priority int
+	Port     int
+	Weight   int
+	Flag     uint8
+	Tag      CAATag
+}
+
+type Response struct {
+	Authoritative bool
+	RCode         int
+	Answers       []Answer
+	Authorities   []Answer
+	Additionals   []Answer
+}
+
+type Resolver interface {
+	Query(ctx context.Context, server, name string, typeRecord RecordType) (*Response, error)
+}
+
+type resolver struct {
+	timeout time.Duration
+}
+
+const (
+	RecordDS     RecordType = "DS"
+	RecordDNSKEY RecordType = "DNSKEY"
+
+	RCodeSuccess   int = 0
+	RCodeNameError int = 3
+	RCodeRefused   int = 5
+
+	dnsTypeCAA    dnsmessage.Type = 257
+	dnsTypeDS     dnsmessage.Type = 43
+	dnsTypeDNSKEY dnsmessage.Type = 48
+
+	defaultResolverTimeout = 5 * time.Second
+	ednsPayloadSize        = 4096
+)
+
+var (
+	queryTypes = map[RecordType]dnsmessage.Type{
+		RecordA:      dnsmessage.TypeA,
+		RecordAAAA:   dnsmessage.TypeAAAA,
+		RecordCNAME:  dnsmessage.TypeCNAME,
+		RecordMX:     dnsmessage.TypeMX,
+		RecordNS:     dnsmessage.TypeNS,
+		RecordTXT:    dnsmessage.TypeTXT,
+		RecordSRV:    dnsmessage.TypeSRV,
+		RecordSOA:    dnsmessage.TypeSOA,
+		RecordCAA:    dnsTypeCAA,
+		RecordDS:     dnsTypeDS,
+		RecordDNSKEY: dnsTypeDNSKEY,
+	}
+
+	ErrTruncatedResponse = errors.New("truncated dns response")
+)
+
+func NewResolver(timeout time.Duration) Resolver {
+	if timeout <= 0 {
+		timeout = defaultResolverTimeout
+	}
+	return &resolver{timeout: timeout}
+}
+
+func (r *resolver) Query(ctx context.Context, server, name string, typeRecord RecordType) (*Response, error) {
+	qType, ok := queryTypes[typeRecord]
+	if !ok {
+		return nil, fmt.Errorf("unsupported query type %q", typeRecord)
+	}
+
+	qName, err := dnsmessage.NewName(strings.TrimSuffix(name, ".") + ".")
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := queryID()
+	if err != nil {
+		return nil, err
+	}
+	query, err := buildQuery(id, qName, qType)
+	if err != nil {
+		return nil, err
+	}
+
+	address := serverAddress(server)
+	resp, err := r.exchange(ctx, "udp", address, query, id)
+	if err == ErrTruncatedResponse {
+		resp, err = r.exchange(ctx, "tcp", address, query, id)
+	}
+	return resp, err
+}
+
+func (r *resolver) exchange(ctx context.Context, network, address string, query []byte, id uint16) (*Response, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, network, address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	var msg dnsmessage.Message
+	if network == "tcp" {
+		framed := make([]byte, 2+len(query))
+		binary.BigEndian.PutUint16(framed, uint16(len(query)))
+		copy(framed[2:], query)
+		if _, err := conn.Write(framed); err != nil {
+			return nil, err
+		}
+		length := make([]byte, 2)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return nil, err
+		}
+		raw := make([]byte, binary.BigEndian.Uint16(length))
+		if _, err := io.ReadFull(conn, raw); err != nil {
+			return nil, err
+		}
+		if err := msg.Unpack(raw); err != nil {
+			return nil, err
+		}
+		if msg.Header.ID != id {
+			return nil, errors.New("dns response id mismatch")
+		}
+	} else {
+		if _, err := conn.Write(query); err != nil {
+			return nil, err
+		}
+		buffer := make([]byte, ednsPayloadSize)
+		for {
+			n, err := conn.Read(buffer)
+			if err != nil {
+				return nil, err
+			}
+			if err := msg.Unpack(buffer[:n]); err == nil && msg.Header.ID == id {
+				break
+			}
+		}
+	}
+
+	if msg.Header.Truncated {
+		return nil, ErrTruncatedResponse
+	}
+
+	return &Response{
+		Authoritative: msg.Header.Authoritative,
+		RCode:         int(msg.Header.RCode),
+		Answers:       convertResources(msg.Answers),
+		Authorities:   convertResources(msg.Authorities),
+		Additionals:   convertResources(msg.Additionals),
+	}, nil
+}
+
+func queryID() (uint16, error) {
+	b := make([]byte, 2)
+	if _, err := rand.Read(b); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(b), nil
+}
+
+func buildQuery(id uint16, name dnsmessage.Name, qType dnsmessage.Type) ([]byte, error) {
+	builder := dnsmessage.NewBuilder(make([]byte, 0, 512), dnsmessage.Header{ID: id})
+	builder.EnableCompression()
+	if err := builder.StartQuestions(); err != nil {
+		return nil, err
+	}
+	if err := builder.Question(dnsmessage.Question{Name: name, Type: qType, Class: dnsmessage.ClassINET}); err != nil {
+		return nil, err
+	}
+	if err := builder.StartAdditionals(); err != nil {
+		return nil, err
+	}
+
+	var opt dnsmessage.ResourceHeader
+	if err := opt.SetEDNS0(ednsPayloadSize, dnsmessage.RCodeSuccess, true); err != nil {
+		return nil, err
+	}
+	if err := builder.OPTResource(opt, dnsmessage.OPTResource{}); err != nil {
+		return nil, err
+	}
+
+	return builder.Finish()
+}
+
+func serverAddress(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(strings.Trim(server, "[]"), "53")
+}
+
+func convertResources(resources []dnsmessage.Resource) []Answer {
+	answers := []Answer{}
+	for _, res := range resources {
+		answer := Answer{
+			Name: canonicalName(res.Header.Name.String()),
+			TTL:  res.Header.TTL,
+		}
+
+		switch body := res.Body.(type) {
+		case *dnsmessage.AResource:
+			answer.Type = RecordA
+			answer.Value = net.IP(body.A[:]).String()
+		case *dnsmessage.AAAAResource:
+			answer.Type = RecordAAAA
+			answer.Value = net.IP(body.AAAA[:]).String()
+		case *dnsmessage.CNAMEResource:
+			answer.Type = RecordCNAME
+			answer.Value = canonicalName(body.CNAME.String())
+		case *dnsmessage.NSResource:
+			answer.Type = RecordNS
+			answer.Value = canonicalName(body.NS.String())
+		case *dnsmessage.MXResource:
+			answer.Type = RecordMX
+			answer.Value = canonicalName(body.MX.String())
+			answer.Priority = int(body.Pref)
+		case *dnsmessage.TXTResource:
+			answer.Type = RecordTXT
+			answer.Value = strings.Join(body.TXT, "")
+		case *dnsmessage.SRVResource:
+			answer.Type = RecordSRV
+			answer.Value = canonicalName(body.Target.String())
+			answer.Priority = int(body.Priority)
+			answer.Port = int(body.Port)
+			answer.Weight = int(body.Weight)
+		case *dnsmessage.SOAResource:
+			answer.Type = RecordSOA
+			answer.Value = strings.Join([]string{
+				canonicalName(body.NS.String()),
+				canonicalName(body.MBox.String()),
+				strconv.FormatUint(uint64(body.Serial), 10),
+				strconv.FormatUint(uint64(body.Refresh), 10),
+				strconv.FormatUint(uint64(body.Retry), 10),
+				strconv.FormatUint(uint64(body.Expire), 10),
+				strconv.FormatUint(uint64(body.MinTTL), 10),
+			}, " ")
+		case *dnsmessage.UnknownResource:
+			if !convertUnknownResource(body, &answer) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		answers = append(answers, answer)
+	}
+	return answers
+}
+
+func convertUnknownResource(body *dnsmessage.UnknownResource, answer *Answer) bool {
+	data := body.Data
+	switch body.Type {
+	case dnsTypeCAA:
+		if len(data) < 2 || len(data) < 2+int(data[1]) {
+			return false
+		}
+		answer.Type = RecordCAA
+		answer.Flag = data[0]
+		answer.Tag = CAATag(strings.ToLower(string(data[2 : 2+int(data[1])])))
+		answer.Value = string(data[2+int(data[1]):])
+	case dnsTypeDS:
+		if len(data) < 4 {
+			return false
+		}
+		answer.Type = RecordDS
+		answer.Value = fmt.Sprintf("%d %d %d %s", binary.BigEndian.Uint16(data[0:2]), data[2], data[3], strings.ToUpper(hex.EncodeToString(data[4:])))
+	case dnsTypeDNSKEY:
+		if len(data) < 4 {
+			return false
+		}
+		answer.Type = RecordDNSKEY
+		answer.Value = fmt.Sprintf("%d %d %d %s", binary.BigEndian.Uint16(data[0:2]), data[2], data[3], base64.StdEncoding.EncodeToString(data[4:]))
+	default:
+		return false
+	}
+	return true
+}
+
+func canonicalName(name string) string {
+	return strings.ToLower(strings.TrimSuffix(name, "."))
+}
+
+func (a Answer) Matches(r *Record) bool {
+	if r == nil || a.Type != r.Type {
+		return false
+	}
+
+	switch r.Type {
+	case RecordA, RecordAAAA:
+		ip := net.ParseIP(r.Value)
+		return ip != nil && ip.Equal(net.ParseIP(a.Value))
+	case RecordCNAME, RecordNS:
+		return canonicalName(r.Value) == a.Value
+	case RecordMX:
+		return canonicalName(r.Value) == a.Value && r.Priority == a.Priority
+	case RecordSRV:
+		return canonicalName(r.Value) == a.Value && r.Priority == a.Priority && r.Port == a.Port && r.Weight == a.Weight
+	case RecordTXT:
+		return JoinTXT(r.Value) == a.Value
+	case RecordCAA:
+		return r.Flag == a.Flag && r.Tag == a.Tag && r.Value == a.Value
+	}
+	return false
+}
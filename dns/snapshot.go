@@ -0,0 +1,267 @@
+package dns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Snapshot struct {
+	DomainName string    `json:"domainname"`
+	TakenAt    time.Time `json:"takenat"`
+	Records    []*Record `json:"records"`
+}
+
+type SnapshotInfo struct {
+	DomainName string
+	TakenAt    time.Time
+}
+
+type SnapshotStorage interface {
+	Save(snapshot *Snapshot) error
+	Load(domainName string, takenAt time.Time) (*Snapshot, error)
+	List(domainName string) ([]SnapshotInfo, error)
+}
+
+type fileSnapshotStorage struct {
+	dir string
+}
+
+const snapshotFileLayout = "20060102T150405.000000000Z"
+
+var ErrSnapshotRestoreIncomplete = errors.New("snapshot restore incomplete")
+
+var snapshotRecordTypes = []RecordType{RecordA, RecordAAAA, RecordCNAME, RecordMX, RecordNS, RecordTXT, RecordSRV, RecordCAA}
+
+func TakeSnapshot(d DNS, domainName string) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		DomainName: strings.ToLower(strings.TrimSuffix(domainName, ".")),
+		TakenAt:    time.Now().UTC(),
+		Records:    []*Record{},
+	}
+
+	for _, t := range snapshotRecordTypes {
+		records, err := searchAllRecords(d, domainName, t)
+		if err != nil {
+			return nil, fmt.Errorf("search %s records: %w", t, err)
+		}
+		for _, r := range records {
+			record, err := r.ToRecord(domainName)
+			if err != nil {
+				return nil, err
+			}
+			snapshot.Records = append(snapshot.Records, record)
+		}
+	}
+
+	return snapshot, nil
+}
+
+func (s *Snapshot) Diff(current *Snapshot) (toDelete, toAdd, toModify []*Record) {
+	target := map[string]*Record{}
+	for _, r := range s.Records {
+		if isApexNS(r) {
+			continue
+		}
+		target[recordKey(r)] = r
+	}
+
+	existing := map[string]*Record{}
+	for _, r := range current.Records {
+		if isApexNS(r) {
+			continue
+		}
+		key := recordKey(r)
+		existing[key] = r
+		want, ok := target[key]
+		switch {
+		case !ok:
+			toDelete = append(toDelete, r)
+		case want.TTL != r.TTL:
+			toModify = append(toModify, want)
+		}
+	}
+
+	for _, r := range s.Records {
+		if isApexNS(r) {
+			continue
+		}
+		if _, ok := existing[recordKey(r)]; !ok {
+			toAdd = append(toAdd, r)
+		}
+	}
+
+	return toDelete, toAdd, toModify
+}
+
+func RestoreSnapshot(d DNS, snapshot *Snapshot, parallelism int) ([]OperationResult, error) {
+	if snapshot == nil {
+		return nil, errors.New("snapshot must not nil")
+	}
+
+	current, err := TakeSnapshot(d, snapshot.DomainName)
+	if err != nil {
+		return nil, err
+	}
+
+	toDelete, toAdd, toModify := snapshot.Diff(current)
+
+	update := NewBatch(d, parallelism)
+	for _, r := range toAdd {
+		update.Add(snapshot.DomainName, r)
+	}
+	for _, r := range toModify {
+		update.Modify(snapshot.DomainName, r, r.Value)
+	}
+	results := update.Execute()
+
+	for _, r := range results {
+		if !r.Success {
+			return results, ErrSnapshotRestoreIncomplete
+		}
+	}
+
+	deletion := NewBatch(d, parallelism)
+	for _, r := range toDelete {
+		deletion.Delete(snapshot.DomainName, r)
+	}
+	results = append(results, deletion.Execute()...)
+
+	for _, r := range results {
+		if !r.Success {
+			return results, ErrSnapshotRestoreIncomplete
+		}
+	}
+	return results, nil
+}
+
+func NewFileSnapshotStorage(dir string) SnapshotStorage {
+	return &fileSnapshotStorage{dir: dir}
+}
+
+func (f *fileSnapshotStorage) Save(snapshot *Snapshot) error {
+	if snapshot == nil {
+		return errors.New("snapshot must not nil")
+	}
+
+	domainDir, err := f.domainDir(snapshot.DomainName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(domainDir, 0o755); err != nil {
+		return err
+	}
+
+	bytesSnapshot, err := json.MarshalIndent(snapshot, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(domainDir, snapshot.TakenAt.UTC().Format(snapshotFileLayout)+".json"), bytesSnapshot, 0o644)
+}
+
+func (f *fileSnapshotStorage) Load(domainName string, takenAt time.Time) (*Snapshot, error) {
+	domainDir, err := f.domainDir(domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	bytesSnapshot, err := os.ReadFile(filepath.Join(domainDir, takenAt.UTC().Format(snapshotFileLayout)+".json"))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := new(Snapshot)
+	if err := json.Unmarshal(bytesSnapshot, snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+func (f *fileSnapshotStorage) List(domainName string) ([]SnapshotInfo, error) {
+	domains := []string{domainName}
+	if len(domainName) <= 0 {
+		entries, err := os.ReadDir(f.dir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				return []SnapshotInfo{}, nil
+			}
+			return nil, err
+		}
+		domains = domains[:0]
+		for _, e := range entries {
+			if e.IsDir() {
+				domains = append(domains, e.Name())
+			}
+		}
+	}
+
+	infos := []SnapshotInfo{}
+	for _, dn := range domains {
+		domainDir, err := f.domainDir(dn)
+		if err != nil {
+			return nil, err
+		}
+		entries, err := os.ReadDir(domainDir)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+				continue
+			}
+			takenAt, err := time.Parse(snapshotFileLayout, strings.TrimSuffix(e.Name(), ".json"))
+			if err != nil {
+				continue
+			}
+			infos = append(infos, SnapshotInfo{DomainName: strings.ToLower(strings.TrimSuffix(dn, ".")), TakenAt: takenAt})
+		}
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		if infos[i].DomainName != infos[j].DomainName {
+			return infos[i].DomainName < infos[j].DomainName
+		}
+		return infos[i].TakenAt.Before(infos[j].TakenAt)
+	})
+	return infos, nil
+}
+
+func (f *fileSnapshotStorage) domainDir(domainName string) (string, error) {
+	domainName = strings.ToLower(strings.TrimSuffix(domainName, "."))
+	if len(domainName) <= 0 || strings.ContainsAny(domainName, `/\`) || strings.HasPrefix(domainName, ".") {
+		return "", fmt.Errorf("invalid domain name %q", domainName)
+	}
+	return filepath.Join(f.dir, domainName), nil
+}
+
+func recordKey(r *Record) string {
+	value := r.Value
+	switch r.Type {
+	case RecordCNAME, RecordMX, RecordNS, RecordSRV:
+		value = strings.ToLower(strings.TrimSuffix(value, "."))
+	}
+	return strings.Join([]string{
+		string(r.Type),
+		strings.ToLower(r.Host),
+		value,
+		strconv.Itoa(r.Priority),
+		strconv.Itoa(r.Port),
+		strconv.Itoa(r.Weight),
+		strconv.Itoa(int(r.Flag)),
+		string(r.Tag),
+	}, "|")
+}
+
+func isApexNS(r *Record) bool {
+	return r.Type == RecordNS && len(r.Host) <= 0
+}
@@ -0,0 +1,150 @@
+package dns
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeZone struct {
+	DNS
+	records []*DNSRecord
+	calls   []string
+	failAdd bool
+}
+
+func (f *fakeZone) SearchingDNSRecords(domainName string, typeRecord RecordType, noOfRecords, pageNo int, host, value string) (*SearchingDNSRecords, error) {
+	found := []*DNSRecord{}
+	if pageNo == 1 {
+		for _, r := range f.records {
+			if RecordType(r.Type) == typeRecord {
+				found = append(found, r)
+			}
+		}
+	}
+	return &SearchingDNSRecords{Recsindb: strconv.Itoa(len(found)), Records: found}, nil
+}
+
+func (f *fakeZone) AddingIPv4AddressRecord(domainName, value, host string, ttl int) (*StdResponse, error) {
+	f.calls = append(f.calls, "add "+host+" "+value)
+	if f.failAdd {
+		return &StdResponse{Status: "Failed", Msg: "Record already exists"}, nil
+	}
+	return &StdResponse{Status: "Success"}, nil
+}
+
+func (f *fakeZone) ModifyingIPv4AddressRecord(domainName, host, currentValue, newValue string, ttl int) (*StdResponse, error) {
+	f.calls = append(f.calls, "modify "+host+" "+newValue+" "+strconv.Itoa(ttl))
+	return &StdResponse{Status: "Success"}, nil
+}
+
+func (f *fakeZone) DeletingIPv4AddressRecord(domainName, host, value string) (*StdResponse, error) {
+	f.calls = append(f.calls, "delete "+host+" "+value)
+	return &StdResponse{Status: "Success"}, nil
+}
+
+func snapshotOf(records ...*Record) *Snapshot {
+	return &Snapshot{DomainName: "example.com", TakenAt: time.Now().UTC(), Records: records}
+}
+
+func TestSnapshotDiff(t *testing.T) {
+	target := snapshotOf(
+		&Record{Type: RecordA, Host: "www", Value: "192.0.2.1", TTL: 3600},
+		&Record{Type: RecordA, Host: "api", Value: "192.0.2.2", TTL: 600},
+		&Record{Type: RecordNS, Value: "ns1.example.net", TTL: 3600},
+	)
+	current := snapshotOf(
+		&Record{Type: RecordA, Host: "www", Value: "192.0.2.1", TTL: 3600},
+		&Record{Type: RecordA, Host: "api", Value: "192.0.2.2", TTL: 3600},
+		&Record{Type: RecordA, Host: "old", Value: "192.0.2.3", TTL: 3600},
+		&Record{Type: RecordNS, Value: "ns2.example.net", TTL: 3600},
+		&Record{Type: RecordCNAME, Host: "mail", Value: "Mail.Example.NET.", TTL: 3600},
+	)
+	target.Records = append(target.Records, &Record{Type: RecordCNAME, Host: "mail", Value: "mail.example.net", TTL: 3600})
+
+	toDelete, toAdd, toModify := target.Diff(current)
+	require.Len(t, toDelete, 1)
+	require.Equal(t, "old", toDelete[0].Host)
+	require.Empty(t, toAdd)
+	require.Len(t, toModify, 1)
+	require.Equal(t, "api", toModify[0].Host)
+	require.Equal(t, 600, toModify[0].TTL)
+
+	toDelete, toAdd, toModify = current.Diff(snapshotOf())
+	require.Empty(t, toDelete)
+	require.Len(t, toAdd, 4)
+	require.Empty(t, toModify)
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	zone := &fakeZone{records: []*DNSRecord{
+		{Type: "A", Host: "www.example.com", Value: "192.0.2.1", TimeToLive: "3600"},
+		{Type: "A", Host: "old.example.com", Value: "192.0.2.9", TimeToLive: "3600"},
+	}}
+	target := snapshotOf(
+		&Record{Type: RecordA, Host: "www", Value: "192.0.2.1", TTL: 600},
+		&Record{Type: RecordA, Host: "new", Value: "192.0.2.2", TTL: 3600},
+	)
+
+	results, err := RestoreSnapshot(zone, target, 1)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	require.Equal(t, []string{"add new 192.0.2.2", "modify www 192.0.2.1 600", "delete old 192.0.2.9"}, zone.calls)
+
+	zone.calls = nil
+	zone.failAdd = true
+	results, err = RestoreSnapshot(zone, target, 1)
+	require.Equal(t, ErrSnapshotRestoreIncomplete, err)
+	require.Len(t, results, 2)
+	require.False(t, results[0].Success)
+	require.True(t, results[1].Success)
+	require.Equal(t, []string{"add new 192.0.2.2", "modify www 192.0.2.1 600"}, zone.calls)
+
+	_, err = RestoreSnapshot(zone, nil, 1)
+	require.Error(t, err)
+}
+
+func TestFileSnapshotStorage(t *testing.T) {
+	storage := NewFileSnapshotStorage(t.TempDir())
+
+	list, err := storage.List("")
+	require.NoError(t, err)
+	require.Empty(t, list)
+
+	first := snapshotOf(&Record{Type: RecordA, Host: "www", Value: "192.0.2.1", TTL: 3600})
+	first.TakenAt = time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	second := snapshotOf(&Record{Type: RecordA, Host: "www", Value: "192.0.2.2", TTL: 3600})
+	second.TakenAt = first.TakenAt.Add(time.Hour)
+	other := &Snapshot{DomainName: "example.org", TakenAt: first.TakenAt, Records: []*Record{}}
+
+	require.NoError(t, storage.Save(second))
+	require.NoError(t, storage.Save(first))
+	require.NoError(t, storage.Save(other))
+	require.Error(t, storage.Save(nil))
+	require.Error(t, storage.Save(&Snapshot{DomainName: "../escape"}))
+
+	loaded, err := storage.Load("example.com", second.TakenAt)
+	require.NoError(t, err)
+	require.Equal(t, "192.0.2.2", loaded.Records[0].Value)
+	require.True(t, second.TakenAt.Equal(loaded.TakenAt))
+
+	_, err = storage.Load("example.com", second.TakenAt.Add(time.Minute))
+	require.Error(t, err)
+
+	list, err = storage.List("example.com")
+	require.NoError(t, err)
+	require.Len(t, list, 2)
+	require.True(t, first.TakenAt.Equal(list[0].TakenAt))
+	require.True(t, second.TakenAt.Equal(list[1].TakenAt))
+
+	list, err = storage.List("")
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	require.Equal(t, "example.org", list[2].DomainName)
+
+	list, err = storage.List("missing.com")
+	require.NoError(t, err)
+	require.Empty(t, list)
+}
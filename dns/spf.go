@@ -0,0 +1,244 @@
+package dns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+)
+
+type SPFQualifier string
+
+type SPFTerm struct {
+	Qualifier SPFQualifier
+	Mechanism string
+	Value     string
+}
+
+type SPF struct {
+	Terms       []SPFTerm
+	Redirect    string
+	Explanation string
+}
+
+const (
+	SPFPass     SPFQualifier = "+"
+	SPFFail     SPFQualifier = "-"
+	SPFSoftFail SPFQualifier = "~"
+	SPFNeutral  SPFQualifier = "?"
+
+	spfVersion     = "v=spf1"
+	spfLookupLimit = 10
+)
+
+var rgxSPFModifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9._-]*=`)
+
+func NewSPF() *SPF {
+	return &SPF{}
+}
+
+func (s *SPF) Include(domainName string) *SPF {
+	return s.add(SPFPass, "include", domainName)
+}
+
+func (s *SPF) IP4(cidr string) *SPF {
+	return s.add(SPFPass, "ip4", cidr)
+}
+
+func (s *SPF) IP6(cidr string) *SPF {
+	return s.add(SPFPass, "ip6", cidr)
+}
+
+func (s *SPF) A(domainName string) *SPF {
+	return s.add(SPFPass, "a", domainName)
+}
+
+func (s *SPF) MX(domainName string) *SPF {
+	return s.add(SPFPass, "mx", domainName)
+}
+
+func (s *SPF) All(q SPFQualifier) *SPF {
+	terms := s.Terms[:0]
+	for _, t := range s.Terms {
+		if t.Mechanism != "all" {
+			terms = append(terms, t)
+		}
+	}
+	s.Terms = terms
+	return s.add(q, "all", "")
+}
+
+func (s *SPF) add(q SPFQualifier, mechanism, value string) *SPF {
+	term := SPFTerm{Qualifier: q, Mechanism: mechanism, Value: value}
+	if n := len(s.Terms); n > 0 && s.Terms[n-1].Mechanism == "all" && mechanism != "all" {
+		s.Terms = append(s.Terms[:n-1], term, s.Terms[n-1])
+		return s
+	}
+	s.Terms = append(s.Terms, term)
+	return s
+}
+
+func ParseSPF(value string) (*SPF, error) {
+	fields := strings.Fields(JoinTXT(value))
+	if len(fields) <= 0 || !strings.EqualFold(fields[0], spfVersion) {
+		return nil, errors.New("spf record must start with " + spfVersion)
+	}
+
+	spf := NewSPF()
+	for _, field := range fields[1:] {
+		lower := strings.ToLower(field)
+		switch {
+		case strings.HasPrefix(lower, "redirect="):
+			spf.Redirect = field[len("redirect="):]
+			continue
+		case strings.HasPrefix(lower, "exp="):
+			spf.Explanation = field[len("exp="):]
+			continue
+		case rgxSPFModifier.MatchString(field):
+			continue
+		}
+
+		term := SPFTerm{Qualifier: SPFPass}
+		switch SPFQualifier(field[:1]) {
+		case SPFPass, SPFFail, SPFSoftFail, SPFNeutral:
+			term.Qualifier = SPFQualifier(field[:1])
+			field = field[1:]
+		}
+
+		idx := strings.IndexAny(field, ":/")
+		switch {
+		case idx < 0:
+			term.Mechanism = strings.ToLower(field)
+		case field[idx] == ':':
+			term.Mechanism = strings.ToLower(field[:idx])
+			term.Value = field[idx+1:]
+		default:
+			term.Mechanism = strings.ToLower(field[:idx])
+			term.Value = field[idx:]
+		}
+		spf.Terms = append(spf.Terms, term)
+	}
+
+	if err := spf.Validate(); err != nil {
+		return nil, err
+	}
+	return spf, nil
+}
+
+func (s *SPF) String() string {
+	parts := []string{spfVersion}
+	for _, t := range s.Terms {
+		term := t.Mechanism
+		if t.Qualifier != SPFPass && len(t.Qualifier) > 0 {
+			term = string(t.Qualifier) + term
+		}
+		if len(t.Value) > 0 {
+			if strings.HasPrefix(t.Value, "/") {
+				term += t.Value
+			} else {
+				term += ":" + t.Value
+			}
+		}
+		parts = append(parts, term)
+	}
+	if len(s.Redirect) > 0 {
+		parts = append(parts, "redirect="+s.Redirect)
+	}
+	if len(s.Explanation) > 0 {
+		parts = append(parts, "exp="+s.Explanation)
+	}
+	return strings.Join(parts, " ")
+}
+
+func (s *SPF) LookupCount() int {
+	count := 0
+	for _, t := range s.Terms {
+		switch t.Mechanism {
+		case "include", "a", "mx", "ptr", "exists":
+			count++
+		}
+	}
+	if len(s.Redirect) > 0 {
+		count++
+	}
+	return count
+}
+
+func (s *SPF) Validate() error {
+	for i, t := range s.Terms {
+		switch t.Qualifier {
+		case SPFPass, SPFFail, SPFSoftFail, SPFNeutral:
+		default:
+			return fmt.Errorf("invalid spf qualifier %q", t.Qualifier)
+		}
+
+		switch t.Mechanism {
+		case "all":
+			if len(t.Value) > 0 {
+				return errors.New("spf all mechanism takes no value")
+			}
+			if i != len(s.Terms)-1 {
+				return errors.New("spf all mechanism must be the last term")
+			}
+		case "include", "exists":
+			if !rgxHostname.MatchString(t.Value) && !strings.Contains(t.Value, "%{") {
+				return fmt.Errorf("invalid spf %s domain %q", t.Mechanism, t.Value)
+			}
+		case "a", "mx", "ptr":
+			domainName := t.Value
+			if idx := strings.Index(domainName, "/"); idx >= 0 {
+				domainName = domainName[:idx]
+			}
+			if len(domainName) > 0 && !rgxHostname.MatchString(domainName) && !strings.Contains(domainName, "%{") {
+				return fmt.Errorf("invalid spf %s domain %q", t.Mechanism, t.Value)
+			}
+		case "ip4", "ip6":
+			ip := net.ParseIP(t.Value)
+			if ip == nil {
+				var err error
+				if ip, _, err = net.ParseCIDR(t.Value); err != nil {
+					return fmt.Errorf("invalid spf %s address %q", t.Mechanism, t.Value)
+				}
+			}
+			if (t.Mechanism == "ip4") != (ip.To4() != nil) {
+				return fmt.Errorf("invalid spf %s address %q", t.Mechanism, t.Value)
+			}
+		default:
+			return fmt.Errorf("unknown spf mechanism %q", t.Mechanism)
+		}
+	}
+
+	if len(s.Redirect) > 0 && !rgxHostname.MatchString(s.Redirect) {
+		return fmt.Errorf("invalid spf redirect domain %q", s.Redirect)
+	}
+
+	if n := s.LookupCount(); n > spfLookupLimit {
+		return fmt.Errorf("spf record requires %d dns lookups, limit is %d", n, spfLookupLimit)
+	}
+
+	return nil
+}
+
+func FindSPF(d DNS, domainName string) (*SPF, *DNSRecord, error) {
+	record, err := findTXTRecord(d, domainName, "", spfVersion)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spf, err := ParseSPF(record.Value)
+	if err != nil {
+		return nil, record, err
+	}
+	return spf, record, nil
+}
+
+func SaveSPF(d DNS, domainName string, spf *SPF, ttl int) (*StdResponse, error) {
+	if spf == nil {
+		return nil, errors.New("spf must not nil")
+	}
+	if err := spf.Validate(); err != nil {
+		return nil, err
+	}
+	return saveTXTRecord(d, domainName, "", spfVersion, spf.String(), ttl)
+}
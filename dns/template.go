@@ -0,0 +1,191 @@
+package dns
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+const (
+	TemplateGoogleWorkspace = "google-workspace"
+	TemplateMicrosoft365    = "microsoft-365"
+	TemplateHosting         = "hosting"
+	TemplateMailAuth        = "mail-auth"
+)
+
+var (
+	templateMutex    = sync.RWMutex{}
+	templateRegistry = map[string]*Template{
+		TemplateGoogleWorkspace: {
+			Name:        TemplateGoogleWorkspace,
+			Description: "Google Workspace mail exchangers and SPF",
+			Records: []TemplateRecord{
+				{Type: RecordMX, Value: "aspmx.l.google.com", Priority: 1},
+				{Type: RecordMX, Value: "alt1.aspmx.l.google.com", Priority: 5},
+				{Type: RecordMX, Value: "alt2.aspmx.l.google.com", Priority: 5},
+				{Type: RecordMX, Value: "alt3.aspmx.l.google.com", Priority: 10},
+				{Type: RecordMX, Value: "alt4.aspmx.l.google.com", Priority: 10},
+				{Type: RecordTXT, Value: "v=spf1 include:_spf.google.com ~all"},
+			},
+		},
+		TemplateMicrosoft365: {
+			Name:        TemplateMicrosoft365,
+			Description: "Microsoft 365 mail exchanger, autodiscover and SPF",
+			Variables:   []string{"MXToken"},
+			Records: []TemplateRecord{
+				{Type: RecordMX, Value: "{{.MXToken}}.mail.protection.outlook.com", Priority: 0},
+				{Type: RecordCNAME, Host: "autodiscover", Value: "autodiscover.outlook.com"},
+				{Type: RecordTXT, Value: "v=spf1 include:spf.protection.outlook.com -all"},
+			},
+		},
+		TemplateHosting: {
+			Name:        TemplateHosting,
+			Description: "Web hosting on a single address with www alias",
+			Variables:   []string{"IP"},
+			Records: []TemplateRecord{
+				{Type: RecordA, Value: "{{.IP}}"},
+				{Type: RecordCNAME, Host: "www", Value: "{{.Domain}}"},
+			},
+		},
+		TemplateMailAuth: {
+			Name:        TemplateMailAuth,
+			Description: "SPF, DKIM and DMARC policies",
+			Variables:   []string{"SPF", "DKIMSelector", "DKIM", "DMARC"},
+			Records: []TemplateRecord{
+				{Type: RecordTXT, Value: "{{.SPF}}"},
+				{Type: RecordTXT, Host: "{{.DKIMSelector}}._domainkey", Value: "{{.DKIM}}"},
+				{Type: RecordTXT, Host: "_dmarc", Value: "{{.DMARC}}"},
+			},
+		},
+	}
+)
+
+func RegisterTemplate(t *Template) error {
+	if t == nil || len(strings.TrimSpace(t.Name)) <= 0 {
+		return errors.New("template name must not empty")
+	}
+	if len(t.Records) <= 0 {
+		return errors.New("template records must not empty")
+	}
+
+	templateMutex.Lock()
+	defer templateMutex.Unlock()
+	if _, ok := templateRegistry[t.Name]; ok {
+		return fmt.Errorf("template %q already registered", t.Name)
+	}
+	templateRegistry[t.Name] = copyTemplate(t)
+	return nil
+}
+
+func LookupTemplate(name string) (*Template, bool) {
+	templateMutex.RLock()
+	defer templateMutex.RUnlock()
+	t, ok := templateRegistry[name]
+	if !ok {
+		return nil, false
+	}
+	return copyTemplate(t), true
+}
+
+func TemplateNames() []string {
+	templateMutex.RLock()
+	defer templateMutex.RUnlock()
+	names := make([]string, 0, len(templateRegistry))
+	for name := range templateRegistry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func copyTemplate(t *Template) *Template {
+	ret := *t
+	ret.Variables = append([]string{}, t.Variables...)
+	ret.Records = append([]TemplateRecord{}, t.Records...)
+	return &ret
+}
+
+func (t *Template) Render(vars TemplateVars) ([]*Record, error) {
+	for _, v := range append([]string{"Domain"}, t.Variables...) {
+		if len(vars[v]) <= 0 {
+			return nil, fmt.Errorf("template %q requires variable %s", t.Name, v)
+		}
+	}
+
+	records := make([]*Record, 0, len(t.Records))
+	for i, tr := range t.Records {
+		host, err := renderTemplateField(t.Name, i, tr.Host, vars)
+		if err != nil {
+			return nil, err
+		}
+		value, err := renderTemplateField(t.Name, i, tr.Value, vars)
+		if err != nil {
+			return nil, err
+		}
+
+		ttl := tr.TTL
+		if ttl <= 0 {
+			ttl = DefaultTTL
+		}
+
+		record := &Record{
+			Type:     tr.Type,
+			Host:     host,
+			Value:    value,
+			TTL:      ttl,
+			Priority: tr.Priority,
+			Port:     tr.Port,
+			Weight:   tr.Weight,
+			Flag:     tr.Flag,
+			Tag:      tr.Tag,
+		}
+		if err := record.Validate(); err != nil {
+			return nil, fmt.Errorf("template %q record %d: %w", t.Name, i, err)
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (t *Template) Apply(d DNS, domainName string, vars TemplateVars, parallelism int) ([]OperationResult, error) {
+	data := TemplateVars{}
+	for k, v := range vars {
+		data[k] = v
+	}
+	data["Domain"] = domainName
+
+	records, err := t.Render(data)
+	if err != nil {
+		return nil, err
+	}
+
+	b := NewBatch(d, parallelism)
+	for _, r := range records {
+		b.Add(domainName, r)
+	}
+
+	return b.Execute(), nil
+}
+
+func renderTemplateField(name string, idx int, field string, vars TemplateVars) (string, error) {
+	if !strings.Contains(field, "{{") {
+		return field, nil
+	}
+
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(field)
+	if err != nil {
+		return "", fmt.Errorf("template %q record %d: %w", name, idx, err)
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, map[string]string(vars)); err != nil {
+		return "", fmt.Errorf("template %q record %d: %w", name, idx, err)
+	}
+
+	return buffer.String(), nil
+}
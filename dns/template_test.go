@@ -0,0 +1,76 @@
+package dns
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateRender(t *testing.T) {
+	tmpl, ok := LookupTemplate(TemplateHosting)
+	require.True(t, ok)
+
+	records, err := tmpl.Render(TemplateVars{"Domain": "example.com", "IP": "192.0.2.10"})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	require.Equal(t, RecordA, records[0].Type)
+	require.Equal(t, "192.0.2.10", records[0].Value)
+	require.Equal(t, DefaultTTL, records[0].TTL)
+	require.Equal(t, "www", records[1].Host)
+	require.Equal(t, "example.com", records[1].Value)
+
+	_, err = tmpl.Render(TemplateVars{"Domain": "example.com"})
+	require.Error(t, err)
+	_, err = tmpl.Render(TemplateVars{"Domain": "example.com", "IP": "not-an-ip"})
+	require.Error(t, err)
+}
+
+func TestTemplateApply(t *testing.T) {
+	c := &fakeCore{}
+	tmpl, ok := LookupTemplate(TemplateMicrosoft365)
+	require.True(t, ok)
+
+	results, err := tmpl.Apply(New(c), "example.com", TemplateVars{"MXToken": "example-com"}, 2)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+	for _, r := range results {
+		require.True(t, r.Success)
+	}
+	require.Equal(t, "example-com.mail.protection.outlook.com", results[0].Operation.Record.Value)
+	require.ElementsMatch(t, []string{"manage/add-mx-record", "manage/add-cname-record", "manage/add-txt-record"}, c.apiNames())
+
+	_, err = tmpl.Apply(New(c), "example.com", TemplateVars{}, 2)
+	require.Error(t, err)
+	require.Len(t, c.calls, 3)
+}
+
+func TestRegisterTemplate(t *testing.T) {
+	custom := &Template{
+		Name:    "test-register",
+		Records: []TemplateRecord{{Type: RecordTXT, Value: "hello"}},
+	}
+	t.Cleanup(func() {
+		templateMutex.Lock()
+		delete(templateRegistry, custom.Name)
+		templateMutex.Unlock()
+	})
+	require.NoError(t, RegisterTemplate(custom))
+	require.Error(t, RegisterTemplate(custom))
+	require.Error(t, RegisterTemplate(&Template{Name: "test-empty"}))
+	require.Error(t, RegisterTemplate(&Template{Records: custom.Records}))
+	require.Contains(t, TemplateNames(), "test-register")
+
+	custom.Records[0].Value = "changed"
+	found, ok := LookupTemplate("test-register")
+	require.True(t, ok)
+	require.Equal(t, "hello", found.Records[0].Value)
+
+	found.Records[0].Value = "mutated"
+	found.Name = "mutated"
+	again, _ := LookupTemplate("test-register")
+	require.Equal(t, "hello", again.Records[0].Value)
+	require.Equal(t, "test-register", again.Name)
+
+	_, ok = LookupTemplate("missing")
+	require.False(t, ok)
+}
@@ -0,0 +1,133 @@
+package dns
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+const (
+	txtChunkSize    = 255
+	searchPageLimit = 50
+)
+
+var ErrRecordNotFound = errors.New("record not found")
+
+func SplitTXT(value string) []string {
+	if len(value) <= txtChunkSize {
+		return []string{value}
+	}
+
+	chunks := make([]string, 0, len(value)/txtChunkSize+1)
+	for len(value) > txtChunkSize {
+		chunks = append(chunks, value[:txtChunkSize])
+		value = value[txtChunkSize:]
+	}
+	if len(value) > 0 {
+		chunks = append(chunks, value)
+	}
+	return chunks
+}
+
+func FormatTXT(value string) string {
+	chunks := SplitTXT(value)
+	if len(chunks) <= 1 {
+		return value
+	}
+
+	quoted := make([]string, len(chunks))
+	replacer := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	for i, c := range chunks {
+		quoted[i] = `"` + replacer.Replace(c) + `"`
+	}
+	return strings.Join(quoted, " ")
+}
+
+func JoinTXT(value string) string {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, `"`) {
+		return value
+	}
+
+	var builder strings.Builder
+	inQuote, escaped := false, false
+	for _, r := range trimmed {
+		switch {
+		case escaped:
+			builder.WriteRune(r)
+			escaped = false
+		case r == '\\' && inQuote:
+			escaped = true
+		case r == '"':
+			inQuote = !inQuote
+		case inQuote:
+			builder.WriteRune(r)
+		}
+	}
+	return builder.String()
+}
+
+func normalizeHost(domainName, host string) string {
+	domainName = strings.ToLower(strings.TrimSuffix(domainName, "."))
+	host = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+	if host == "@" || host == domainName {
+		return ""
+	}
+	return strings.TrimSuffix(host, "."+domainName)
+}
+
+func searchAllRecords(d DNS, domainName string, typeRecord RecordType) ([]*DNSRecord, error) {
+	records := []*DNSRecord{}
+	for page := 1; ; page++ {
+		result, err := d.SearchingDNSRecords(domainName, typeRecord, searchPageLimit, page, "", "")
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, result.Records...)
+
+		total, err := strconv.Atoi(result.Recsindb)
+		if err != nil || len(result.Records) <= 0 || len(records) >= total {
+			break
+		}
+	}
+	return records, nil
+}
+
+func findTXTRecord(d DNS, domainName, host, prefix string) (*DNSRecord, error) {
+	records, err := searchAllRecords(d, domainName, RecordTXT)
+	if err != nil {
+		return nil, err
+	}
+
+	host = normalizeHost(domainName, host)
+	for _, r := range records {
+		if normalizeHost(domainName, r.Host) != host {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(JoinTXT(r.Value))), strings.ToLower(prefix)) {
+			return r, nil
+		}
+	}
+	return nil, ErrRecordNotFound
+}
+
+func saveTXTRecord(d DNS, domainName, host, prefix, value string, ttl int) (*StdResponse, error) {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	value = FormatTXT(value)
+	if err := (&Record{Type: RecordTXT, Host: host, Value: value, TTL: ttl}).Validate(); err != nil {
+		return nil, err
+	}
+
+	current, err := findTXTRecord(d, domainName, host, prefix)
+	switch {
+	case err == ErrRecordNotFound:
+		return d.AddingTXTRecord(domainName, value, host, ttl)
+	case err != nil:
+		return nil, err
+	}
+
+	return d.ModifyingTXTRecord(domainName, host, current.Value, value, ttl)
+}
@@ -23,9 +23,46 @@ type DNSRecord struct {
 	Type       string `json:"type,omitempty"`
 	Host       string `json:"host,omitempty"`
 	Value      string `json:"value,omitempty"`
+	Priority   string `json:"priority,omitempty"`
+	Port       string `json:"port,omitempty"`
+	Weight     string `json:"weight,omitempty"`
 }
 
+type Record struct {
+	Type     RecordType `json:"type" validate:"required"`
+	Host     string     `json:"host"`
+	Value    string     `json:"value" validate:"required"`
+	TTL      int        `json:"ttl" validate:"gte=0"`
+	Priority int        `json:"priority,omitempty" validate:"gte=0,lte=65535"`
+	Port     int        `json:"port,omitempty" validate:"gte=0,lte=65535"`
+	Weight   int        `json:"weight,omitempty" validate:"gte=0,lte=65535"`
+	Flag     uint8      `json:"flag,omitempty"`
+	Tag      CAATag     `json:"tag,omitempty"`
+}
+
+type TemplateRecord struct {
+	Type     RecordType
+	Host     string
+	Value    string
+	TTL      int
+	Priority int
+	Port     int
+	Weight   int
+	Flag     uint8
+	Tag      CAATag
+}
+
+type Template struct {
+	Name        string
+	Description string
+	Variables   []string
+	Records     []TemplateRecord
+}
+
+type TemplateVars map[string]string
+
 type RecordType string
+type CAATag string
 
 const (
 	RecordA     RecordType = "A"
@@ -35,4 +72,37 @@ const (
 	RecordNS    RecordType = "NS"
 	RecordSRV   RecordType = "SRV"
 	RecordAAAA  RecordType = "AAAA"
+	RecordCAA   RecordType = "CAA"
+	RecordSOA   RecordType = "SOA"
+
+	CAAIssue     CAATag = "issue"
+	CAAIssueWild CAATag = "issuewild"
+	CAAIodef     CAATag = "iodef"
+
+	CAAFlagNone     uint8 = 0
+	CAAFlagCritical uint8 = 128
+
+	DefaultTTL int = 14400
+)
+
+type OperationKind string
+
+type Operation struct {
+	Kind       OperationKind
+	DomainName string
+	Record     *Record
+	NewValue   string
+}
+
+type OperationResult struct {
+	Operation Operation
+	Success   bool
+	Message   string
+	Err       error
+}
+
+const (
+	OperationAdd    OperationKind = "add"
+	OperationModify OperationKind = "modify"
+	OperationDelete OperationKind = "delete"
 )
@@ -0,0 +1,214 @@
+package domain
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type CNSOperationKind string
+
+type CNSOperation struct {
+	Kind    CNSOperationKind
+	Host    string
+	NewHost string
+	IPs     []string
+	OldIP   string
+	NewIP   string
+}
+
+const (
+	CNSAdd       CNSOperationKind = "add"
+	CNSRename    CNSOperationKind = "rename"
+	CNSModifyIP  CNSOperationKind = "modify-ip"
+	CNSDeleteIPs CNSOperationKind = "delete-ips"
+)
+
+var rgxNameServer = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+func (d *domain) SetChildNameServers(orderID string, cns map[string][]net.IP) ([]*NameServersResponse, error) {
+	detail, err := d.GetRegistrationOrderDetails(orderID, []string{"NsDetails"})
+	if err != nil {
+		return nil, err
+	}
+
+	ops, err := PlanChildNameServers(detail.DomainName, detail.CNS, cns)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*NameServersResponse, 0, len(ops))
+	for _, op := range ops {
+		var result *NameServersResponse
+		switch op.Kind {
+		case CNSAdd:
+			result, err = d.AddChildNameServer(orderID, op.Host, op.IPs)
+		case CNSRename:
+			result, err = d.ModifyChildNameServerHostName(orderID, op.Host, op.NewHost)
+		case CNSModifyIP:
+			result, err = d.ModifyChildNameServerIPAddress(orderID, op.Host, op.OldIP, op.NewIP)
+		case CNSDeleteIPs:
+			result, err = d.DeletingChildNameServerIPAddress(orderID, op.Host, op.IPs)
+		}
+		if err != nil {
+			return results, fmt.Errorf("%s %s: %w", op.Kind, op.Host, err)
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func PlanChildNameServers(domainName string, current map[string][]string, desired map[string][]net.IP) ([]CNSOperation, error) {
+	domainName = canonicalHost(domainName)
+
+	want := map[string][]string{}
+	for host, ips := range desired {
+		host = canonicalHost(host)
+		if !rgxNameServer.MatchString(host) || (len(domainName) > 0 && !isSubdomain(host, domainName)) {
+			return nil, fmt.Errorf("invalid child name server %q", host)
+		}
+		if len(ips) <= 0 {
+			return nil, fmt.Errorf("child name server %q must have at least one ip address", host)
+		}
+		normalized := []string{}
+		for _, ip := range ips {
+			if ip == nil || (ip.To4() == nil && ip.To16() == nil) {
+				return nil, fmt.Errorf("invalid ip address for child name server %q", host)
+			}
+			normalized = append(normalized, ip.String())
+		}
+		want[host] = uniqueSorted(normalized)
+	}
+
+	have := map[string][]string{}
+	for host, ips := range current {
+		normalized := []string{}
+		for _, v := range ips {
+			if ip := net.ParseIP(strings.TrimSpace(v)); ip != nil {
+				normalized = append(normalized, ip.String())
+			}
+		}
+		have[canonicalHost(host)] = uniqueSorted(normalized)
+	}
+
+	added, removed := []string{}, []string{}
+	for host := range want {
+		if _, ok := have[host]; !ok {
+			added = append(added, host)
+		}
+	}
+	for host := range have {
+		if _, ok := want[host]; !ok {
+			removed = append(removed, host)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	ops := []CNSOperation{}
+
+	renamed := map[string]bool{}
+	for _, oldHost := range removed {
+		for _, newHost := range added {
+			if renamed[newHost] || !sameIPs(have[oldHost], want[newHost]) {
+				continue
+			}
+			ops = append(ops, CNSOperation{Kind: CNSRename, Host: oldHost, NewHost: newHost})
+			renamed[oldHost], renamed[newHost] = true, true
+			break
+		}
+	}
+
+	for _, host := range added {
+		if !renamed[host] {
+			ops = append(ops, CNSOperation{Kind: CNSAdd, Host: host, IPs: want[host]})
+		}
+	}
+
+	hosts := []string{}
+	for host := range want {
+		if _, ok := have[host]; ok {
+			hosts = append(hosts, host)
+		}
+	}
+	sort.Strings(hosts)
+	for _, host := range hosts {
+		ops = append(ops, planCNSIPs(host, have[host], want[host])...)
+	}
+
+	for _, host := range removed {
+		if !renamed[host] && len(have[host]) > 0 {
+			ops = append(ops, CNSOperation{Kind: CNSDeleteIPs, Host: host, IPs: have[host]})
+		}
+	}
+
+	return ops, nil
+}
+
+func planCNSIPs(host string, have, want []string) []CNSOperation {
+	toAdd, toRemove := diffStrings(want, have), diffStrings(have, want)
+	if len(toAdd) <= 0 && len(toRemove) <= 0 {
+		return nil
+	}
+
+	pairs := len(toAdd)
+	if len(toRemove) < pairs {
+		pairs = len(toRemove)
+	}
+	modifyCost := pairs
+	if len(toAdd) != len(toRemove) {
+		modifyCost++
+	}
+	batchCost := 0
+	if len(toAdd) > 0 {
+		batchCost++
+	}
+	if len(toRemove) > 0 {
+		batchCost++
+	}
+
+	ops := []CNSOperation{}
+	if modifyCost < batchCost {
+		for i := 0; i < pairs; i++ {
+			ops = append(ops, CNSOperation{Kind: CNSModifyIP, Host: host, OldIP: toRemove[i], NewIP: toAdd[i]})
+		}
+		toAdd, toRemove = toAdd[pairs:], toRemove[pairs:]
+	}
+	if len(toAdd) > 0 {
+		ops = append(ops, CNSOperation{Kind: CNSAdd, Host: host, IPs: toAdd})
+	}
+	if len(toRemove) > 0 {
+		ops = append(ops, CNSOperation{Kind: CNSDeleteIPs, Host: host, IPs: toRemove})
+	}
+	return ops
+}
+
+func diffStrings(a, b []string) []string {
+	exists := map[string]bool{}
+	for _, v := range b {
+		exists[v] = true
+	}
+	ret := []string{}
+	for _, v := range a {
+		if !exists[v] {
+			ret = append(ret, v)
+		}
+	}
+	return ret
+}
+
+func uniqueSorted(values []string) []string {
+	seen := map[string]bool{}
+	ret := []string{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			ret = append(ret, v)
+		}
+	}
+	sort.Strings(ret)
+	return ret
+}
@@ -4,8 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -30,12 +32,17 @@ type Domain interface {
 	ModifyChildNameServerHostName(orderID, oldCNS, newCNS string) (*NameServersResponse, error)
 	ModifyChildNameServerIPAddress(orderID, cns, oldIP, newIP string) (*NameServersResponse, error)
 	DeletingChildNameServerIPAddress(orderID, cns string, ips []string) (*NameServersResponse, error)
+	SetChildNameServers(orderID string, cns map[string][]net.IP) ([]*NameServersResponse, error)
 	ModifyContacts(orderID, regContactID, adminContactID, techContactID, billingContactID string, sixtyDayLockOptout, designatedAgent bool, attrName, attrValue string) (*ModifyAuthCodeResponse, error)
 	ModifyPrivacyProtectionStatus(orderID string, protectPrivacy bool, reason string) (*ModifyPrivacyProtectionStatusResponse, error)
 	ModifyAuthCode(orderID, authCode string) (*ModifyAuthCodeResponse, error)
 	ApplyTheftProtectionLock(orderID string) (*TheftProtectionLockResponse, error)
 	RemoveTheftProtectionLock(orderID string) (*TheftProtectionLockResponse, error)
 	GetTheListOfLocksAppliedOnDomainName(orderID string) (*GetTheListOfLocksAppliedOnDomainNameResponse, error)
+	ResendTransferApprovalMail(orderID string) error
+	SearchOrderSummaries(criteria OrderCriteria, offset, limit uint16) (*OrderSearchResult, error)
+	ResendVerification(orderID string) error
+	VerificationStatus(orderID string) (*VerificationStatus, error)
 	CancelTransfer(orderID string) (*CancelTransferResponse, error)
 	Suspend(orderID, reason string) (*TheftProtectionLockResponse, error)
 	Unsuspend(orderID string) (*TheftProtectionLockResponse, error)
@@ -309,6 +316,77 @@ func (d *domain) SearchOrders(criteria OrderCriteria) error {
 	return nil
 }
 
+func (d *domain) SearchOrderSummaries(criteria OrderCriteria, offset, limit uint16) (*OrderSearchResult, error) {
+	if offset <= 0 || limit <= 0 {
+		return nil, errors.New("offset or limit must greater than zero")
+	}
+
+	urlValues, err := criteria.UrlValues()
+	if err != nil {
+		return nil, err
+	}
+	urlValues.Add("no-of-records", strconv.FormatUint(uint64(limit), 10))
+	urlValues.Add("page-no", strconv.FormatUint(uint64(offset), 10))
+
+	resp, err := d.core.CallApi(http.MethodGet, "domains", "search", urlValues)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return nil, err
+		}
+		return nil, errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	replacer := strings.NewReplacer(`"orders.`, `"`, `"entity.`, `"`, `"entitytype.`, `"`, `"domorder.`, `"`)
+	strResp := replacer.Replace(string(bytesResp))
+
+	var buffer map[string]core.JSONBytes
+	if err := json.Unmarshal([]byte(strResp), &buffer); err != nil {
+		return nil, err
+	}
+
+	result := &OrderSearchResult{
+		RequestedLimit:  limit,
+		RequestedOffset: offset,
+		Orders:          []OrderSummary{},
+	}
+	keys := []int{}
+	for key, dataBytes := range buffer {
+		switch {
+		case key == "recsindb":
+			result.TotalMatched, err = strconv.Atoi(strings.Trim(string(dataBytes), `"`))
+			if err != nil {
+				result.TotalMatched = 0
+			}
+		case core.RgxNumber.MatchString(key):
+			idx, _ := strconv.Atoi(key)
+			keys = append(keys, idx)
+		}
+	}
+
+	sort.Ints(keys)
+	for _, idx := range keys {
+		var order OrderSummary
+		if err := json.Unmarshal(buffer[strconv.Itoa(idx)], &order); err != nil {
+			return nil, err
+		}
+		result.Orders = append(result.Orders, order)
+	}
+
+	return result, nil
+}
+
 func (d *domain) GetCustomerDefaultNameServers(customerID string) ([]string, error) {
 	data := make(url.Values)
 	data.Add("customer-id", customerID)
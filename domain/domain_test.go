@@ -1,11 +1,21 @@
 package domain
 
 import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/xpartacvs/go-resellerclub/actions"
 	"github.com/xpartacvs/go-resellerclub/core"
+	"github.com/xpartacvs/go-resellerclub/dns"
 )
 
 var d = New(core.New(
@@ -78,3 +88,357 @@ func TestGetTheListOfLocksAppliedOnDomainName(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, res)
 }
+
+type fakeResolver map[string]*dns.Response
+
+func (f fakeResolver) Query(ctx context.Context, server, name string, typeRecord dns.RecordType) (*dns.Response, error) {
+	if resp, ok := f[server+" "+name+" "+string(typeRecord)]; ok {
+		return resp, nil
+	}
+	if resp, ok := f[server]; ok {
+		return resp, nil
+	}
+	return nil, errors.New("connection refused")
+}
+
+func TestHealthCheckOrder(t *testing.T) {
+	dnskey := "257 3 8 AQOeiiR0GOMYkDshWoSKz9XzfwJr1AYtsmx3TGkJaNXVbfi/2pHm822aJ5iI9BMzNXxeYCmZDRD99WYwYqUSdjMmmAphXdvxegXd/M5+X7OrzKBaMbCVdFLUUh6DhweJBjEVv5f2wwjM9XzcnOf+EPbtG9DMBmADjFDc2w/rljwvFw=="
+	key, err := dns.ParseDNSKEY(dnskey)
+	require.NoError(t, err)
+	ds, err := key.ToDS("example.com", dns.DigestSHA256)
+	require.NoError(t, err)
+
+	nsAnswers := []dns.Answer{
+		{Name: "example.com", Type: dns.RecordNS, Value: "ns1.example.com"},
+		{Name: "example.com", Type: dns.RecordNS, Value: "ns2.example.net"},
+	}
+	resolver := fakeResolver{
+		"192.0.2.1 example.com NS":       {Authoritative: true, Answers: nsAnswers},
+		"192.0.2.1 ns1.example.com A":    {Authoritative: true, Answers: []dns.Answer{{Name: "ns1.example.com", Type: dns.RecordA, Value: "192.0.2.1"}}},
+		"192.0.2.1 ns1.example.com AAAA": {Authoritative: true, Answers: []dns.Answer{}},
+		"192.0.2.1 example.com DNSKEY":   {Authoritative: true, Answers: []dns.Answer{{Name: "example.com", Type: dns.RecordDNSKEY, Value: dnskey}}},
+		"ns2.example.net":                {Authoritative: true, Answers: nsAnswers},
+	}
+
+	detail := &OrderDetail{
+		OrderID:    "1",
+		DomainName: "Example.com",
+		NS1:        "ns1.example.com",
+		NS2:        "ns2.example.net.",
+		CNS:        map[string][]string{"ns1.example.com": {"192.0.2.1"}},
+		DNSSec: []DNSSecRecord{{
+			KeyTag:     core.JSONUint16(ds.KeyTag),
+			Algorithm:  core.JSONUint16(ds.Algorithm),
+			DigestType: core.JSONUint16(ds.DigestType),
+			Digest:     ds.Digest,
+		}},
+	}
+
+	report, err := NewHealthChecker(nil, resolver).CheckOrder(context.Background(), detail)
+	require.NoError(t, err)
+	require.True(t, report.Healthy(), "%v", report.Problems)
+	require.Equal(t, []string{"ns1.example.com", "ns2.example.net"}, report.ZoneNameServers)
+
+	detail.NS3 = "ns3.example.com"
+	detail.DNSSec[0].Digest = "00"
+	report, err = NewHealthChecker(nil, resolver).CheckOrder(context.Background(), detail)
+	require.NoError(t, err)
+
+	issues := map[HealthIssue]int{}
+	for _, p := range report.Problems {
+		issues[p.Issue]++
+	}
+	require.Equal(t, map[HealthIssue]int{
+		IssueLameDelegation: 1,
+		IssueNSMismatch:     2,
+		IssueMissingGlue:    1,
+		IssueDNSSECMismatch: 1,
+	}, issues)
+}
+
+func TestPlanChildNameServers(t *testing.T) {
+	current := map[string][]string{
+		"ns1.example.com":  {"192.0.2.1"},
+		"ns2.example.com":  {"192.0.2.2", "192.0.2.3"},
+		"old.example.com":  {"192.0.2.9"},
+		"gone.example.com": {"192.0.2.10"},
+	}
+	desired := map[string][]net.IP{
+		"NS1.example.com.": {net.ParseIP("192.0.2.1")},
+		"ns2.example.com":  {net.ParseIP("192.0.2.2"), net.ParseIP("192.0.2.4")},
+		"new.example.com":  {net.ParseIP("192.0.2.9")},
+		"ns3.example.com":  {net.ParseIP("2001:db8::53")},
+	}
+
+	ops, err := PlanChildNameServers("example.com", current, desired)
+	require.NoError(t, err)
+	require.Equal(t, []CNSOperation{
+		{Kind: CNSRename, Host: "old.example.com", NewHost: "new.example.com"},
+		{Kind: CNSAdd, Host: "ns3.example.com", IPs: []string{"2001:db8::53"}},
+		{Kind: CNSModifyIP, Host: "ns2.example.com", OldIP: "192.0.2.3", NewIP: "192.0.2.4"},
+		{Kind: CNSDeleteIPs, Host: "gone.example.com", IPs: []string{"192.0.2.10"}},
+	}, ops)
+
+	_, err = PlanChildNameServers("example.com", nil, map[string][]net.IP{"ns1.example.net": {net.ParseIP("192.0.2.1")}})
+	require.Error(t, err)
+
+	_, err = PlanChildNameServers("example.com", nil, map[string][]net.IP{"ns1.example.com": {nil}})
+	require.Error(t, err)
+}
+
+type fakeNameServerDomain struct {
+	Domain
+	detail   OrderDetail
+	modified []string
+}
+
+func (f *fakeNameServerDomain) GetRegistrationOrderDetails(orderID string, options []string) (*OrderDetail, error) {
+	detail := f.detail
+	return &detail, nil
+}
+
+func (f *fakeNameServerDomain) ModifyNameServers(orderID string, ns []string) (*NameServersResponse, error) {
+	f.modified = ns
+	f.detail.NS1, f.detail.NS2 = ns[0], ns[1]
+	return &NameServersResponse{EaqID: "1", ActionStatus: "Success"}, nil
+}
+
+type fakeNameServerActions struct {
+	actions.Actions
+	status actions.ActionStatus
+	waited []string
+}
+
+func (f *fakeNameServerActions) Wait(ctx context.Context, eaqID string, interval time.Duration) (*actions.Action, error) {
+	f.waited = append(f.waited, eaqID)
+	action := &actions.Action{EaqID: eaqID, Status: f.status, StatusDescription: "Registry rejected the request"}
+	if !f.status.IsSuccess() {
+		return action, actions.ErrActionFailed
+	}
+	return action, nil
+}
+
+func TestNameServerWorkflow(t *testing.T) {
+	resolver := fakeResolver{
+		"ns1.example.net": {Authoritative: true},
+		"ns2.example.net": {Authoritative: true},
+		"ns3.example.net": {Authoritative: false},
+	}
+	fake := &fakeNameServerDomain{detail: OrderDetail{DomainName: "example.com", NS1: "ns1.old.net", NS2: "ns2.old.net"}}
+	tracker := &fakeNameServerActions{status: actions.StatusSuccess}
+	workflow := NewNameServerWorkflow(fake, tracker, resolver, time.Millisecond)
+
+	_, err := workflow.Validate(context.Background(), "example.com", nil)
+	require.Error(t, err)
+	normalized, err := workflow.Validate(context.Background(), "example.com", []string{"NS1.example.net."})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ns1.example.net"}, normalized)
+	_, err = workflow.Validate(context.Background(), "example.com", []string{"ns1.example.net", "bad_host"})
+	require.Error(t, err)
+	_, err = workflow.Validate(context.Background(), "example.com", []string{"ns1.example.net", "ns3.example.net"})
+	require.True(t, errors.Is(err, ErrNameServerNotAuthoritative))
+
+	res, err := workflow.Change(context.Background(), "1", []string{"NS1.example.net.", "ns2.example.net"})
+	require.NoError(t, err)
+	require.Equal(t, "1", res.EaqID)
+	require.Equal(t, []string{"ns1.example.net", "ns2.example.net"}, fake.modified)
+	require.Equal(t, []string{"1"}, tracker.waited)
+
+	res, err = workflow.Change(context.Background(), "1", []string{"ns2.example.net", "ns1.example.net"})
+	require.NoError(t, err)
+	require.Equal(t, string(actions.StatusNoAction), res.ActionStatus)
+	require.Empty(t, res.EaqID)
+	require.Len(t, tracker.waited, 1)
+
+	fake.detail.NS1, fake.detail.NS2 = "ns1.old.net", "ns2.old.net"
+	tracker.status = actions.StatusFailed
+	res, err = workflow.Change(context.Background(), "1", []string{"ns1.example.net", "ns2.example.net"})
+	require.True(t, errors.Is(err, actions.ErrActionFailed))
+	require.Contains(t, err.Error(), "registry rejected the request")
+	require.Equal(t, "1", res.EaqID)
+}
+
+type fakeTransferDomain struct {
+	Domain
+	statuses []string
+	resent   int
+}
+
+func (f *fakeTransferDomain) ValidatingTransferRequest(domainName string) (bool, error) {
+	return domainName == "example.com", nil
+}
+
+func (f *fakeTransferDomain) Transfer(domainName, authCode, customerID, regContactID, adminContactID, techContactID, billingContactID, invoiceOption string, purchasePrivacy, protectPrivacy, autoRenew bool, ns []string, attrName, attrValue string, purchasePremiumDNS bool) (*RegisterResponse, error) {
+	return &RegisterResponse{EntityID: "1", EaqID: "2", ActionStatus: "InProcess"}, nil
+}
+
+func (f *fakeTransferDomain) GetRegistrationOrderDetails(orderID string, options []string) (*OrderDetail, error) {
+	status := f.statuses[0]
+	if len(f.statuses) > 1 {
+		f.statuses = f.statuses[1:]
+	}
+	return &OrderDetail{OrderID: orderID, CurrentStatus: status}, nil
+}
+
+func (f *fakeTransferDomain) ResendTransferApprovalMail(orderID string) error {
+	f.resent++
+	return nil
+}
+
+func TestTransferWorkflow(t *testing.T) {
+	req := &TransferRequest{
+		DomainName:       "example.com",
+		AuthCode:         "secret",
+		CustomerID:       "1",
+		RegContactID:     "1",
+		AdminContactID:   "1",
+		TechContactID:    "1",
+		BillingContactID: "1",
+		InvoiceOption:    "NoInvoice",
+	}
+
+	fake := &fakeTransferDomain{statuses: []string{"InActive", "InActive", "Active"}}
+	workflow := NewTransferWorkflow(fake, nil, TransferOptions{PollInterval: time.Millisecond, ResendInterval: time.Nanosecond, MaxResends: 1})
+
+	status, err := workflow.Start(req)
+	require.NoError(t, err)
+	require.Equal(t, TransferPending, status.State)
+
+	status, err = workflow.Wait(context.Background(), status)
+	require.NoError(t, err)
+	require.Equal(t, TransferCompleted, status.State)
+	require.Equal(t, 1, fake.resent)
+
+	fake = &fakeTransferDomain{statuses: []string{"InActive"}}
+	workflow = NewTransferWorkflow(fake, nil, TransferOptions{PollInterval: time.Millisecond, Timeout: 5 * time.Millisecond})
+	status, err = workflow.Start(req)
+	require.NoError(t, err)
+	status, err = workflow.Wait(context.Background(), status)
+	require.NoError(t, err)
+	require.Equal(t, TransferTimedOut, status.State)
+
+	req.DomainName = "example.net"
+	_, err = workflow.Start(req)
+	require.Equal(t, ErrTransferNotEligible, err)
+}
+
+type fakeTransferOutDomain struct {
+	Domain
+	detail         OrderDetail
+	locked         bool
+	customerLocked bool
+	authCode       string
+	rejectRotate   bool
+}
+
+func (f *fakeTransferOutDomain) GetRegistrationOrderDetails(orderID string, options []string) (*OrderDetail, error) {
+	detail := f.detail
+	return &detail, nil
+}
+
+func (f *fakeTransferOutDomain) GetTheListOfLocksAppliedOnDomainName(orderID string) (*GetTheListOfLocksAppliedOnDomainNameResponse, error) {
+	return &GetTheListOfLocksAppliedOnDomainNameResponse{TransferLock: f.locked, CustomerLock: f.customerLocked}, nil
+}
+
+func (f *fakeTransferOutDomain) RemoveTheftProtectionLock(orderID string) (*TheftProtectionLockResponse, error) {
+	f.locked = false
+	return &TheftProtectionLockResponse{}, nil
+}
+
+func (f *fakeTransferOutDomain) ModifyAuthCode(orderID, authCode string) (*ModifyAuthCodeResponse, error) {
+	if f.rejectRotate {
+		return &ModifyAuthCodeResponse{ActionStatus: "Failed", ActionStatusDesc: "Auth code does not meet registry rules"}, nil
+	}
+	f.authCode = authCode
+	return &ModifyAuthCodeResponse{}, nil
+}
+
+func TestTransferOut(t *testing.T) {
+	code, err := GenerateAuthCode()
+	require.NoError(t, err)
+	require.Len(t, code, 16)
+	require.True(t, strings.ContainsAny(code, "0123456789"))
+	require.True(t, strings.ContainsAny(code, "!@#$%^*()-_=+"))
+
+	old := core.JSONTime(time.Now().Add(-90 * 24 * time.Hour))
+	fake := &fakeTransferOutDomain{locked: true, detail: OrderDetail{DomainName: "example.com", DomSecret: "current", CreationTime: old}}
+
+	result, err := NewTransferOut(fake, SixtyDayLock).Prepare("1", false)
+	require.NoError(t, err)
+	require.True(t, result.Unlocked)
+	require.False(t, result.Rotated)
+	require.Equal(t, "current", result.AuthCode)
+
+	result, err = NewTransferOut(fake, SixtyDayLock).Prepare("1", true)
+	require.NoError(t, err)
+	require.True(t, result.Rotated)
+	require.Equal(t, fake.authCode, result.AuthCode)
+
+	fake.rejectRotate = true
+	result, err = NewTransferOut(fake, SixtyDayLock).Prepare("1", true)
+	require.EqualError(t, err, "auth code does not meet registry rules")
+	require.False(t, result.Rotated)
+	require.Equal(t, "current", result.AuthCode)
+
+	fake.rejectRotate = false
+	fake.locked, fake.customerLocked = true, true
+	_, err = NewTransferOut(fake, SixtyDayLock).Prepare("1", false)
+	require.Equal(t, ErrCustomerLockActive, err)
+	require.True(t, fake.locked)
+
+	fake.detail.CreationTime = core.JSONTime(time.Now().Add(-10 * 24 * time.Hour))
+	_, err = NewTransferOut(fake, SixtyDayLock).Prepare("1", false)
+	require.Equal(t, ErrSixtyDayLock, err)
+}
+
+type fakeCore struct {
+	requests []url.Values
+}
+
+func (f *fakeCore) CallApi(method, namespace, apiName string, data url.Values) (*http.Response, error) {
+	f.requests = append(f.requests, data)
+	body := ""
+	switch apiName {
+	case "search":
+		body = `{"recsonpage":"3","recsindb":"3","1":{"orders.orderid":"103","entity.description":"three.com","entity.currentstatus":"Active","orders.endtime":"1700000000"},"2":{"orders.orderid":"101","entity.description":"one.com","entity.currentstatus":"Active","orders.endtime":"1700000000"},"3":{"orders.orderid":"102","entity.description":"two.com","entity.currentstatus":"Active","orders.endtime":"1700000000"}}`
+	case "details":
+		switch data.Get("order-id") {
+		case "101":
+			body = `{"orderid":"101","domainname":"one.com","registrantcontactid":"7","raaVerificationStatus":"Pending","raaVerificationStartTime":"1600000000"}`
+		case "102":
+			body = `{"orderid":"102","domainname":"two.com","registrantcontactid":"8","raaVerificationStatus":"Verified","raaVerificationStartTime":"1600000000"}`
+		default:
+			body = `{"orderid":"103","domainname":"three.com","registrantcontactid":"9"}`
+		}
+	case "raa/resend-verification":
+		body = "true"
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (f *fakeCore) IsProduction() bool {
+	return false
+}
+
+func TestUnverifiedRegistrants(t *testing.T) {
+	c := &fakeCore{}
+	dom := New(c)
+
+	result, err := dom.SearchOrderSummaries(OrderCriteria{Criteria: core.Criteria{CustomerIDs: []string{"5"}}}, 1, 10)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.TotalMatched)
+	require.Equal(t, "three.com", result.Orders[0].DomainName)
+	require.Equal(t, "5", c.requests[0].Get("customer-id"))
+
+	report, err := UnverifiedRegistrants(dom, OrderCriteria{})
+	require.NoError(t, err)
+	require.Len(t, report, 2)
+	require.Equal(t, "101", report[0].OrderID)
+	require.Equal(t, time.Unix(1600000000, 0).Add(RaaVerificationPeriod), report[0].Deadline)
+	require.True(t, report[0].Overdue(time.Now()))
+	require.Equal(t, "103", report[1].OrderID)
+	require.Empty(t, report[1].Status)
+	require.True(t, report[1].Deadline.IsZero())
+
+	require.NoError(t, dom.ResendVerification("101"))
+}
@@ -0,0 +1,293 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xpartacvs/go-resellerclub/dns"
+)
+
+type HealthIssue string
+
+type HealthProblem struct {
+	Issue   HealthIssue
+	Server  string
+	Message string
+}
+
+type HealthReport struct {
+	OrderID             string
+	DomainName          string
+	RegistryNameServers []string
+	ZoneNameServers     []string
+	Problems            []HealthProblem
+	CheckedAt           time.Time
+}
+
+type HealthChecker interface {
+	Check(ctx context.Context, orderID string) (*HealthReport, error)
+	CheckOrder(ctx context.Context, detail *OrderDetail) (*HealthReport, error)
+}
+
+type healthChecker struct {
+	domain   Domain
+	resolver dns.Resolver
+}
+
+const (
+	IssueNSMismatch     HealthIssue = "ns-mismatch"
+	IssueLameDelegation HealthIssue = "lame-delegation"
+	IssueMissingGlue    HealthIssue = "missing-glue"
+	IssueGlueMismatch   HealthIssue = "glue-mismatch"
+	IssueDNSSECMismatch HealthIssue = "dnssec-mismatch"
+)
+
+func NewHealthChecker(d Domain, r dns.Resolver) HealthChecker {
+	if r == nil {
+		r = dns.NewResolver(0)
+	}
+	return &healthChecker{
+		domain:   d,
+		resolver: r,
+	}
+}
+
+func (o *OrderDetail) NameServers() []string {
+	ns := []string{}
+	for _, n := range []string{o.NS1, o.NS2, o.NS3, o.NS4, o.NS5, o.NS6} {
+		if n = canonicalHost(n); len(n) > 0 {
+			ns = append(ns, n)
+		}
+	}
+	return ns
+}
+
+func (r *HealthReport) Healthy() bool {
+	return len(r.Problems) <= 0
+}
+
+func (h *healthChecker) Check(ctx context.Context, orderID string) (*HealthReport, error) {
+	if h.domain == nil {
+		return nil, errors.New("domain client must not nil")
+	}
+
+	detail, err := h.domain.GetRegistrationOrderDetails(orderID, []string{"NsDetails", "DNSSECDetails"})
+	if err != nil {
+		return nil, err
+	}
+	return h.CheckOrder(ctx, detail)
+}
+
+func (h *healthChecker) CheckOrder(ctx context.Context, detail *OrderDetail) (*HealthReport, error) {
+	if detail == nil {
+		return nil, errors.New("order detail must not nil")
+	}
+
+	domainName := canonicalHost(detail.DomainName)
+	report := &HealthReport{
+		OrderID:             detail.OrderID,
+		DomainName:          domainName,
+		RegistryNameServers: detail.NameServers(),
+		ZoneNameServers:     []string{},
+		Problems:            []HealthProblem{},
+		CheckedAt:           time.Now(),
+	}
+
+	glue := map[string][]string{}
+	for host, ips := range detail.CNS {
+		glue[canonicalHost(host)] = ips
+	}
+
+	authoritative := []string{}
+	zoneNS := map[string]bool{}
+	for _, ns := range report.RegistryNameServers {
+		server := h.serverAddress(ns, glue)
+		resp, err := h.resolver.Query(ctx, server, domainName, dns.RecordNS)
+		switch {
+		case err != nil:
+			report.addProblem(IssueLameDelegation, ns, err.Error())
+			continue
+		case resp.RCode != dns.RCodeSuccess:
+			report.addProblem(IssueLameDelegation, ns, fmt.Sprintf("server answered with rcode %d", resp.RCode))
+			continue
+		case !resp.Authoritative:
+			report.addProblem(IssueLameDelegation, ns, "server is not authoritative for the zone")
+			continue
+		}
+
+		authoritative = append(authoritative, server)
+		served := []string{}
+		for _, a := range resp.Answers {
+			if a.Type == dns.RecordNS && a.Name == domainName {
+				served = append(served, a.Value)
+				zoneNS[a.Value] = true
+			}
+		}
+		if !sameHosts(report.RegistryNameServers, served) {
+			report.addProblem(IssueNSMismatch, ns, fmt.Sprintf("registry has [%s] but zone serves [%s]", strings.Join(report.RegistryNameServers, ", "), strings.Join(sortedHosts(served), ", ")))
+		}
+	}
+	for ns := range zoneNS {
+		report.ZoneNameServers = append(report.ZoneNameServers, ns)
+	}
+	sort.Strings(report.ZoneNameServers)
+
+	h.checkGlue(ctx, report, glue, authoritative)
+	h.checkDNSSEC(ctx, report, detail.DNSSec, authoritative)
+
+	return report, nil
+}
+
+func (h *healthChecker) checkGlue(ctx context.Context, report *HealthReport, glue map[string][]string, authoritative []string) {
+	for _, ns := range report.RegistryNameServers {
+		if !isSubdomain(ns, report.DomainName) {
+			continue
+		}
+		if len(glue[ns]) <= 0 {
+			report.addProblem(IssueMissingGlue, ns, "in-zone nameserver has no child nameserver registered")
+		}
+	}
+
+	if len(authoritative) <= 0 {
+		return
+	}
+
+	hosts := make([]string, 0, len(glue))
+	for host := range glue {
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+
+	for _, host := range hosts {
+		served := []string{}
+		for _, t := range []dns.RecordType{dns.RecordA, dns.RecordAAAA} {
+			resp, err := h.resolver.Query(ctx, authoritative[0], host, t)
+			if err != nil {
+				report.addProblem(IssueGlueMismatch, host, err.Error())
+				break
+			}
+			for _, a := range resp.Answers {
+				if a.Type == t && a.Name == host {
+					served = append(served, a.Value)
+				}
+			}
+		}
+		if !sameIPs(glue[host], served) {
+			report.addProblem(IssueGlueMismatch, host, fmt.Sprintf("registry glue [%s] but zone serves [%s]", strings.Join(glue[host], ", "), strings.Join(served, ", ")))
+		}
+	}
+}
+
+func (h *healthChecker) checkDNSSEC(ctx context.Context, report *HealthReport, records []DNSSecRecord, authoritative []string) {
+	if len(records) <= 0 || len(authoritative) <= 0 {
+		return
+	}
+
+	resp, err := h.resolver.Query(ctx, authoritative[0], report.DomainName, dns.RecordDNSKEY)
+	if err != nil {
+		report.addProblem(IssueDNSSECMismatch, authoritative[0], err.Error())
+		return
+	}
+
+	keys := []*dns.DNSKEY{}
+	for _, a := range resp.Answers {
+		if a.Type != dns.RecordDNSKEY {
+			continue
+		}
+		key, err := dns.ParseDNSKEY(a.Value)
+		if err != nil {
+			report.addProblem(IssueDNSSECMismatch, authoritative[0], err.Error())
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) <= 0 {
+		report.addProblem(IssueDNSSECMismatch, authoritative[0], "registry has ds records but zone serves no dnskey")
+		return
+	}
+
+	for _, r := range records {
+		want := &dns.DS{
+			KeyTag:     r.KeyTag.ToUint16(),
+			Algorithm:  uint8(r.Algorithm),
+			DigestType: uint8(r.DigestType),
+			Digest:     strings.ToUpper(strings.ReplaceAll(r.Digest, " ", "")),
+		}
+
+		matched := false
+		for _, key := range keys {
+			got, err := key.ToDS(report.DomainName, want.DigestType)
+			if err == nil && got.Matches(want) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			report.addProblem(IssueDNSSECMismatch, authoritative[0], fmt.Sprintf("ds %d %d %d has no matching dnskey", want.KeyTag, want.Algorithm, want.DigestType))
+		}
+	}
+}
+
+func (h *healthChecker) serverAddress(ns string, glue map[string][]string) string {
+	if ips := glue[ns]; len(ips) > 0 {
+		return ips[0]
+	}
+	return ns
+}
+
+func (r *HealthReport) addProblem(issue HealthIssue, server, message string) {
+	r.Problems = append(r.Problems, HealthProblem{
+		Issue:   issue,
+		Server:  server,
+		Message: message,
+	})
+}
+
+func canonicalHost(host string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(host), "."))
+}
+
+func isSubdomain(host, domainName string) bool {
+	return strings.HasSuffix(host, "."+domainName)
+}
+
+func sortedHosts(hosts []string) []string {
+	ret := make([]string, 0, len(hosts))
+	for _, h := range hosts {
+		ret = append(ret, canonicalHost(h))
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func sameHosts(a, b []string) bool {
+	x, y := sortedHosts(a), sortedHosts(b)
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sameIPs(a, b []string) bool {
+	normalize := func(ips []string) []string {
+		ret := []string{}
+		for _, v := range ips {
+			if ip := net.ParseIP(strings.TrimSpace(v)); ip != nil {
+				ret = append(ret, ip.String())
+			}
+		}
+		sort.Strings(ret)
+		return ret
+	}
+	return sameHosts(normalize(a), normalize(b))
+}
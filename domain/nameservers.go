@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/xpartacvs/go-resellerclub/actions"
+	"github.com/xpartacvs/go-resellerclub/dns"
+)
+
+type NameServerWorkflow interface {
+	Validate(ctx context.Context, domainName string, ns []string) ([]string, error)
+	Change(ctx context.Context, orderID string, ns []string) (*NameServersResponse, error)
+}
+
+type nameServerWorkflow struct {
+	domain   Domain
+	actions  actions.Actions
+	resolver dns.Resolver
+	interval time.Duration
+}
+
+const defaultNameServerPollInterval = 15 * time.Second
+
+var ErrNameServerNotAuthoritative = errors.New("name server is not authoritative for the zone")
+
+func NewNameServerWorkflow(d Domain, a actions.Actions, r dns.Resolver, pollInterval time.Duration) NameServerWorkflow {
+	if pollInterval <= 0 {
+		pollInterval = defaultNameServerPollInterval
+	}
+	return &nameServerWorkflow{
+		domain:   d,
+		actions:  a,
+		resolver: r,
+		interval: pollInterval,
+	}
+}
+
+func (w *nameServerWorkflow) Validate(ctx context.Context, domainName string, ns []string) ([]string, error) {
+	if len(ns) <= 0 {
+		return nil, errors.New("name servers must not empty")
+	}
+
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, n := range ns {
+		n = canonicalHost(n)
+		if !rgxNameServer.MatchString(n) {
+			return nil, fmt.Errorf("invalid name server %q", n)
+		}
+		if seen[n] {
+			return nil, fmt.Errorf("duplicate name server %q", n)
+		}
+		seen[n] = true
+		normalized = append(normalized, n)
+	}
+
+	if w.resolver == nil {
+		return normalized, nil
+	}
+
+	for _, n := range normalized {
+		resp, err := w.resolver.Query(ctx, n, canonicalHost(domainName), dns.RecordSOA)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", n, err)
+		}
+		if !resp.Authoritative || resp.RCode != dns.RCodeSuccess {
+			return nil, fmt.Errorf("%s: %w", n, ErrNameServerNotAuthoritative)
+		}
+	}
+
+	return normalized, nil
+}
+
+func (w *nameServerWorkflow) Change(ctx context.Context, orderID string, ns []string) (*NameServersResponse, error) {
+	if w.domain == nil {
+		return nil, errors.New("domain client must not nil")
+	}
+
+	detail, err := w.domain.GetRegistrationOrderDetails(orderID, []string{"NsDetails"})
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := w.Validate(ctx, detail.DomainName, ns)
+	if err != nil {
+		return nil, err
+	}
+	if sameHosts(detail.NameServers(), normalized) {
+		return &NameServersResponse{
+			EntityID:         orderID,
+			ActionStatus:     string(actions.StatusNoAction),
+			ActionStatusDesc: "Name servers are already set",
+		}, nil
+	}
+
+	result, err := w.domain.ModifyNameServers(orderID, normalized)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(result.ActionStatus, string(actions.StatusFailed)) || strings.EqualFold(result.Status, "error") {
+		return result, errors.New(strings.ToLower(result.ActionStatusDesc))
+	}
+	if w.actions == nil || len(result.EaqID) <= 0 {
+		return result, nil
+	}
+
+	action, err := w.actions.Wait(ctx, result.EaqID, w.interval)
+	if err == actions.ErrActionFailed && action != nil && len(action.StatusDescription) > 0 {
+		return result, fmt.Errorf("%w: %s", err, strings.ToLower(action.StatusDescription))
+	}
+	return result, err
+}
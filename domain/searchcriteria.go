@@ -22,7 +22,7 @@ type OrderCriteria struct {
 	PrivacyStatus   PrivacyState        `validate:"omitempty" query:"privacy-enabled,omitempty"`
 	ShowChildOrders bool                `validate:"omitempty" query:"show-child-orders,omitempty"`
 	TimeExpiryStart time.Time           `validate:"omitempty" query:"expiry-date-start,omitempty"`
-	TimeExpiryEnd   time.Time           `validate:"omitempty" query:"expiry-date-start,omitempty"`
+	TimeExpiryEnd   time.Time           `validate:"omitempty" query:"expiry-date-end,omitempty"`
 }
 
 func (c OrderCriteria) UrlValues() (url.Values, error) {
@@ -105,5 +105,14 @@ func (c OrderCriteria) UrlValues() (url.Values, error) {
 	}
 
 	wg.Wait()
+
+	baseValues, err := c.Criteria.UrlValues()
+	if err != nil {
+		return url.Values{}, err
+	}
+	for key, values := range baseValues {
+		urlValues[key] = append(urlValues[key], values...)
+	}
+
 	return urlValues, nil
 }
@@ -0,0 +1,227 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/xpartacvs/go-resellerclub/actions"
+	"github.com/xpartacvs/go-resellerclub/core"
+)
+
+type TransferState string
+
+type TransferRequest struct {
+	DomainName         string   `validate:"required"`
+	AuthCode           string   `validate:"required"`
+	CustomerID         string   `validate:"required,number"`
+	RegContactID       string   `validate:"required,number"`
+	AdminContactID     string   `validate:"required,number"`
+	TechContactID      string   `validate:"required,number"`
+	BillingContactID   string   `validate:"required,number"`
+	InvoiceOption      string   `validate:"required,oneof=NoInvoice PayInvoice KeepInvoice OnlyAdd"`
+	PurchasePrivacy    bool     `validate:"omitempty"`
+	ProtectPrivacy     bool     `validate:"omitempty"`
+	AutoRenew          bool     `validate:"omitempty"`
+	NameServers        []string `validate:"omitempty,dive,hostname"`
+	AttrName           string   `validate:"omitempty"`
+	AttrValue          string   `validate:"omitempty"`
+	PurchasePremiumDNS bool     `validate:"omitempty"`
+}
+
+type TransferStatus struct {
+	State       TransferState
+	DomainName  string
+	OrderID     string
+	EaqID       string
+	Detail      *OrderDetail
+	Action      *actions.Action
+	ResendCount int
+	LastResend  time.Time
+	StartedAt   time.Time
+	Message     string
+}
+
+type TransferOptions struct {
+	PollInterval   time.Duration
+	ResendInterval time.Duration
+	MaxResends     int
+	Timeout        time.Duration
+}
+
+type TransferWorkflow interface {
+	Start(req *TransferRequest) (*TransferStatus, error)
+	Poll(status *TransferStatus) (*TransferStatus, error)
+	Wait(ctx context.Context, status *TransferStatus) (*TransferStatus, error)
+}
+
+type transferWorkflow struct {
+	domain  Domain
+	actions actions.Actions
+	options TransferOptions
+}
+
+const (
+	TransferPending   TransferState = "pending"
+	TransferCompleted TransferState = "completed"
+	TransferRejected  TransferState = "rejected"
+	TransferCancelled TransferState = "cancelled"
+	TransferTimedOut  TransferState = "timed-out"
+)
+
+var (
+	DefaultTransferOptions = TransferOptions{
+		PollInterval:   time.Hour,
+		ResendInterval: 24 * time.Hour,
+		MaxResends:     3,
+		Timeout:        7 * 24 * time.Hour,
+	}
+
+	ErrTransferNotEligible = errors.New("domain is not eligible for transfer")
+)
+
+func NewTransferWorkflow(d Domain, a actions.Actions, opts TransferOptions) TransferWorkflow {
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultTransferOptions.PollInterval
+	}
+	if opts.ResendInterval <= 0 {
+		opts.ResendInterval = DefaultTransferOptions.ResendInterval
+	}
+	if opts.MaxResends < 0 {
+		opts.MaxResends = 0
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTransferOptions.Timeout
+	}
+	return &transferWorkflow{
+		domain:  d,
+		actions: a,
+		options: opts,
+	}
+}
+
+func (s TransferState) IsTerminal() bool {
+	return s != TransferPending
+}
+
+func (w *transferWorkflow) Start(req *TransferRequest) (*TransferStatus, error) {
+	if req == nil {
+		return nil, errors.New("transfer request must not nil")
+	}
+	if err := validator.New().Struct(req); err != nil {
+		return nil, err
+	}
+
+	domainName := canonicalHost(req.DomainName)
+	eligible, err := w.domain.ValidatingTransferRequest(domainName)
+	if err != nil {
+		return nil, err
+	}
+	if !eligible {
+		return nil, ErrTransferNotEligible
+	}
+
+	resp, err := w.domain.Transfer(domainName, req.AuthCode, req.CustomerID, req.RegContactID, req.AdminContactID, req.TechContactID, req.BillingContactID, req.InvoiceOption, req.PurchasePrivacy, req.ProtectPrivacy, req.AutoRenew, req.NameServers, req.AttrName, req.AttrValue, req.PurchasePremiumDNS)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TransferStatus{
+		State:      TransferPending,
+		DomainName: domainName,
+		OrderID:    resp.EntityID,
+		EaqID:      resp.EaqID,
+		StartedAt:  time.Now(),
+		LastResend: time.Now(),
+		Message:    resp.ActionStatusDesc,
+	}
+	if strings.EqualFold(resp.ActionStatus, string(actions.StatusFailed)) || strings.EqualFold(resp.Status, "error") {
+		status.State = TransferRejected
+	}
+	return status, nil
+}
+
+func (w *transferWorkflow) Poll(status *TransferStatus) (*TransferStatus, error) {
+	if status == nil || len(status.OrderID) <= 0 {
+		return nil, errors.New("transfer status must have order id")
+	}
+	if status.State.IsTerminal() {
+		return status, nil
+	}
+
+	detail, err := w.domain.GetRegistrationOrderDetails(status.OrderID, []string{"OrderDetails"})
+	if err != nil {
+		return nil, err
+	}
+	status.Detail = detail
+
+	switch core.EntityStatus(detail.CurrentStatus) {
+	case core.StatusActive:
+		status.State = TransferCompleted
+		return status, nil
+	case core.StatusDeleted, core.StatusArchived:
+		status.State = TransferRejected
+		return status, nil
+	}
+
+	if w.actions != nil && len(status.EaqID) > 0 {
+		action, err := w.actions.Get(status.EaqID)
+		if err != nil && err != actions.ErrActionNotFound {
+			return nil, err
+		}
+		if action != nil {
+			status.Action = action
+			status.Message = action.StatusDescription
+			switch action.Status {
+			case actions.StatusSuccess:
+				status.State = TransferCompleted
+				return status, nil
+			case actions.StatusFailed:
+				status.State = TransferRejected
+				return status, nil
+			case actions.StatusCancelled:
+				status.State = TransferCancelled
+				return status, nil
+			}
+		}
+	}
+
+	if time.Since(status.StartedAt) >= w.options.Timeout {
+		status.State = TransferTimedOut
+		return status, nil
+	}
+
+	if status.ResendCount < w.options.MaxResends && time.Since(status.LastResend) >= w.options.ResendInterval {
+		if err := w.domain.ResendTransferApprovalMail(status.OrderID); err != nil {
+			return nil, err
+		}
+		status.ResendCount++
+		status.LastResend = time.Now()
+	}
+
+	return status, nil
+}
+
+func (w *transferWorkflow) Wait(ctx context.Context, status *TransferStatus) (*TransferStatus, error) {
+	ticker := time.NewTicker(w.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		var err error
+		status, err = w.Poll(status)
+		if err != nil {
+			return nil, err
+		}
+		if status.State.IsTerminal() {
+			return status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package domain
+
+import (
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/xpartacvs/go-resellerclub/core"
+)
+
+type TransferOutPolicy func(detail *OrderDetail) error
+
+type TransferOutResult struct {
+	OrderID    string
+	DomainName string
+	AuthCode   string
+	Rotated    bool
+	Unlocked   bool
+}
+
+type TransferOut interface {
+	Prepare(orderID string, rotateAuthCode bool) (*TransferOutResult, error)
+}
+
+type transferOut struct {
+	domain   Domain
+	policies []TransferOutPolicy
+}
+
+const (
+	authCodeLength  = 16
+	authCodeLower   = "abcdefghijkmnpqrstuvwxyz"
+	authCodeUpper   = "ABCDEFGHJKLMNPQRSTUVWXYZ"
+	authCodeDigits  = "23456789"
+	authCodeSpecial = "!@#$%^*()-_=+"
+
+	sixtyDayLockPeriod = 60 * 24 * time.Hour
+)
+
+var (
+	ErrSixtyDayLock       = errors.New("domain is within the 60-day transfer lock")
+	ErrTransferLockActive = errors.New("transfer lock is still applied")
+	ErrCustomerLockActive = errors.New("customer lock is applied")
+)
+
+func NewTransferOut(d Domain, policies ...TransferOutPolicy) TransferOut {
+	return &transferOut{
+		domain:   d,
+		policies: policies,
+	}
+}
+
+// SixtyDayLock only sees the registry's sixtydaylock order status and the
+// order creation time. Order details carry no transfer-in or registrant
+// change timestamps, so those locks are caught only when the registry has
+// flagged the order with sixtydaylock.
+func SixtyDayLock(detail *OrderDetail) error {
+	for _, s := range detail.OrderStatus {
+		if strings.EqualFold(s, "sixtydaylock") {
+			return ErrSixtyDayLock
+		}
+	}
+	created := detail.CreationTime.ToTime()
+	if !created.IsZero() && time.Since(created) < sixtyDayLockPeriod {
+		return ErrSixtyDayLock
+	}
+	return nil
+}
+
+func GenerateAuthCode() (string, error) {
+	classes := []string{authCodeLower, authCodeUpper, authCodeDigits, authCodeSpecial}
+	all := strings.Join(classes, "")
+
+	code := make([]byte, authCodeLength)
+	for i := range code {
+		charset := all
+		if i < len(classes) {
+			charset = classes[i]
+		}
+		c, err := randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		code[i] = c
+	}
+
+	for i := len(code) - 1; i > 0; i-- {
+		j, err := rand.Int(rand.Reader, big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", err
+		}
+		code[i], code[j.Int64()] = code[j.Int64()], code[i]
+	}
+
+	return string(code), nil
+}
+
+func (t *transferOut) Prepare(orderID string, rotateAuthCode bool) (*TransferOutResult, error) {
+	detail, err := t.domain.GetRegistrationOrderDetails(orderID, []string{"All"})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policy := range t.policies {
+		if err := policy(detail); err != nil {
+			return nil, err
+		}
+	}
+
+	result := &TransferOutResult{
+		OrderID:    orderID,
+		DomainName: detail.DomainName,
+	}
+
+	locks, err := t.domain.GetTheListOfLocksAppliedOnDomainName(orderID)
+	if err != nil {
+		return nil, err
+	}
+	if locks.CustomerLock {
+		return nil, ErrCustomerLockActive
+	}
+	// theft protection is the transferlock; the customerlock is set by the
+	// customer and cannot be lifted from here
+	if locks.TransferLock {
+		if _, err := t.domain.RemoveTheftProtectionLock(orderID); err != nil {
+			return nil, err
+		}
+		locks, err = t.domain.GetTheListOfLocksAppliedOnDomainName(orderID)
+		if err != nil {
+			return nil, err
+		}
+		if locks.TransferLock {
+			return nil, ErrTransferLockActive
+		}
+		result.Unlocked = true
+	}
+
+	result.AuthCode = detail.DomSecret
+	if rotateAuthCode || len(result.AuthCode) <= 0 {
+		authCode, err := GenerateAuthCode()
+		if err != nil {
+			return nil, err
+		}
+		resp, err := t.domain.ModifyAuthCode(orderID, authCode)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(resp.ActionStatus, "failed") || strings.EqualFold(resp.Status, "error") {
+			if len(resp.ActionStatusDesc) <= 0 {
+				return result, core.ErrRcOperationFailed
+			}
+			return result, errors.New(strings.ToLower(resp.ActionStatusDesc))
+		}
+		result.AuthCode = authCode
+		result.Rotated = true
+	}
+
+	return result, nil
+}
+
+func randomChar(charset string) (byte, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(int64(len(charset))))
+	if err != nil {
+		return 0, err
+	}
+	return charset[n.Int64()], nil
+}
@@ -68,53 +68,81 @@ type OrderDetail struct {
 		Enabled  core.JSONBool `json:"enabled"`
 		Eligible core.JSONBool `json:"eligible"`
 	} `json:"gdpr"`
-	CustomerID                 string          `json:"customerid"`
-	Addons                     []string        `json:"addons"`
-	BulkWhoIsOptOut            string          `json:"bulkwhoisoptout"`
-	TechContactID              string          `json:"techcontactid"`
-	IsImmediateReseller        core.JSONBool   `json:"isImmediateReseller"`
-	CreationTime               core.JSONTime   `json:"creationtime"`
-	DNSSec                     []string        `json:"dnssec"`
-	JumpConditions             []string        `json:"jumpConditions"`
-	RaaVerificationStartTime   core.JSONTime   `json:"raaVerificationStartTime"`
-	CNS                        struct{}        `json:"cns"`
-	Paused                     core.JSONBool   `json:"paused"`
-	Admincontact               Contact         `json:"admincontact"`
-	BillingContactID           string          `json:"billingcontactid"`
-	PrivacyProtectedAllowed    core.JSONBool   `json:"privacyprotectedallowed"`
-	DomSecret                  string          `json:"domsecret"`
-	PremiumDNSAllowed          core.JSONBool   `json:"premiumdnsallowed"`
-	ServiceProviderID          string          `json:"serviceproviderid"`
-	Classname                  string          `json:"classname"`
-	ResellerCost               core.JSONUint16 `json:"resellercost"`
-	OrderStatus                []string        `json:"orderstatus"`
-	EaqID                      string          `json:"eaqid"`
-	EndTime                    core.JSONTime   `json:"endtime"`
-	BillingContact             Contact         `json:"billingcontact"`
-	AutoRenewTermType          string          `json:"autoRenewTermType"`
-	RaaVerificationStatus      string          `json:"raaVerificationStatus"`
-	EntityID                   string          `json:"entityid"`
-	Recurring                  core.JSONBool   `json:"recurring"`
-	ProductKey                 string          `json:"productkey"`
-	NS1                        string          `json:"ns1"`
-	NS2                        string          `json:"ns2"`
-	NS3                        string          `json:"ns3"`
-	NS4                        string          `json:"ns4"`
-	NS5                        string          `json:"ns5"`
-	NS6                        string          `json:"ns6"`
-	ActionCompleted            core.JSONUint16 `json:"actioncompleted"`
-	RegistrantContact          Contact         `json:"registrantcontact"`
-	EntityTypeID               string          `json:"entitytypeid"`
-	AutoRenewAttemptDuration   core.JSONUint16 `json:"autoRenewAttemptDuration"`
-	CustomerCost               core.JSONFloat  `json:"customercost"`
-	DomainStatus               []string        `json:"domainstatus"`
-	OrderSuspendedByParent     core.JSONBool   `json:"orderSuspendedByParent"`
-	MoneyBackPeriod            core.JSONUint16 `json:"moneybackperiod"`
-	TechContact                Contact         `json:"techcontact"`
-	RegistrantContactID        string          `json:"registrantcontactid"`
-	AdminContactID             string          `json:"admincontactid"`
-	IsOrderSuspendedUponExpiry core.JSONBool   `json:"isOrderSuspendedUponExpiry"`
-	IsPrivacyProtected         core.JSONBool   `json:"isprivacyprotected"`
+	CustomerID                 string              `json:"customerid"`
+	Addons                     []string            `json:"addons"`
+	BulkWhoIsOptOut            string              `json:"bulkwhoisoptout"`
+	TechContactID              string              `json:"techcontactid"`
+	IsImmediateReseller        core.JSONBool       `json:"isImmediateReseller"`
+	CreationTime               core.JSONTime       `json:"creationtime"`
+	DNSSec                     []DNSSecRecord      `json:"dnssec"`
+	JumpConditions             []string            `json:"jumpConditions"`
+	RaaVerificationStartTime   core.JSONTime       `json:"raaVerificationStartTime"`
+	CNS                        map[string][]string `json:"cns"`
+	Paused                     core.JSONBool       `json:"paused"`
+	Admincontact               Contact             `json:"admincontact"`
+	BillingContactID           string              `json:"billingcontactid"`
+	PrivacyProtectedAllowed    core.JSONBool       `json:"privacyprotectedallowed"`
+	DomSecret                  string              `json:"domsecret"`
+	PremiumDNSAllowed          core.JSONBool       `json:"premiumdnsallowed"`
+	ServiceProviderID          string              `json:"serviceproviderid"`
+	Classname                  string              `json:"classname"`
+	ResellerCost               core.JSONUint16     `json:"resellercost"`
+	OrderStatus                []string            `json:"orderstatus"`
+	EaqID                      string              `json:"eaqid"`
+	EndTime                    core.JSONTime       `json:"endtime"`
+	BillingContact             Contact             `json:"billingcontact"`
+	AutoRenewTermType          string              `json:"autoRenewTermType"`
+	RaaVerificationStatus      string              `json:"raaVerificationStatus"`
+	EntityID                   string              `json:"entityid"`
+	Recurring                  core.JSONBool       `json:"recurring"`
+	ProductKey                 string              `json:"productkey"`
+	NS1                        string              `json:"ns1"`
+	NS2                        string              `json:"ns2"`
+	NS3                        string              `json:"ns3"`
+	NS4                        string              `json:"ns4"`
+	NS5                        string              `json:"ns5"`
+	NS6                        string              `json:"ns6"`
+	ActionCompleted            core.JSONUint16     `json:"actioncompleted"`
+	RegistrantContact          Contact             `json:"registrantcontact"`
+	EntityTypeID               string              `json:"entitytypeid"`
+	AutoRenewAttemptDuration   core.JSONUint16     `json:"autoRenewAttemptDuration"`
+	CustomerCost               core.JSONFloat      `json:"customercost"`
+	DomainStatus               []string            `json:"domainstatus"`
+	OrderSuspendedByParent     core.JSONBool       `json:"orderSuspendedByParent"`
+	MoneyBackPeriod            core.JSONUint16     `json:"moneybackperiod"`
+	TechContact                Contact             `json:"techcontact"`
+	RegistrantContactID        string              `json:"registrantcontactid"`
+	AdminContactID             string              `json:"admincontactid"`
+	IsOrderSuspendedUponExpiry core.JSONBool       `json:"isOrderSuspendedUponExpiry"`
+	IsPrivacyProtected         core.JSONBool       `json:"isprivacyprotected"`
+}
+
+type OrderSummary struct {
+	OrderID        string        `json:"orderid"`
+	EntityID       string        `json:"entityid"`
+	DomainName     string        `json:"description"`
+	CustomerID     string        `json:"customerid"`
+	CurrentStatus  string        `json:"currentstatus"`
+	EntityTypeID   string        `json:"entitytypeid"`
+	EntityTypeKey  string        `json:"entitytypekey"`
+	EntityTypeName string        `json:"entitytypename"`
+	AutoRenew      core.JSONBool `json:"autorenew"`
+	CreationTime   core.JSONTime `json:"creationtime"`
+	EndTime        core.JSONTime `json:"endtime"`
+}
+
+type OrderSearchResult struct {
+	RequestedLimit  uint16
+	RequestedOffset uint16
+	TotalMatched    int
+	Orders          []OrderSummary
+}
+
+type DNSSecRecord struct {
+	KeyTag     core.JSONUint16 `json:"keytag"`
+	Algorithm  core.JSONUint16 `json:"algorithm"`
+	DigestType core.JSONUint16 `json:"digesttype"`
+	Digest     string          `json:"digest"`
 }
 
 type NameServersResponse struct {
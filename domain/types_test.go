@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderDetailDecode(t *testing.T) {
+	body := `{
+		"orderid":"101",
+		"domainname":"example.com",
+		"resellercost":"40000",
+		"moneybackperiod":"4",
+		"dnssec":[
+			{"keytag":"60485","algorithm":"8","digesttype":"2","digest":"D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A"},
+			{"keytag":"2371","algorithm":"13","digesttype":"2","digest":"C988EC423E3880EB8DD8A46FE06CA230EE23F35B578F64ECF2B20ED4DE9D4F2E"}
+		],
+		"cns":{"ns1.example.com":["192.0.2.1","2001:db8::1"],"ns2.example.com":["192.0.2.2"]}
+	}`
+
+	var detail OrderDetail
+	require.NoError(t, json.Unmarshal([]byte(body), &detail))
+	require.Equal(t, uint16(40000), detail.ResellerCost.ToUint16())
+	require.Equal(t, uint16(4), detail.MoneyBackPeriod.ToUint16())
+
+	require.Len(t, detail.DNSSec, 2)
+	require.Equal(t, uint16(60485), detail.DNSSec[0].KeyTag.ToUint16())
+	require.Equal(t, uint16(8), detail.DNSSec[0].Algorithm.ToUint16())
+	require.Equal(t, uint16(2), detail.DNSSec[0].DigestType.ToUint16())
+	require.Equal(t, "D4B7D520E7BB5F0F67674A0CCEB1E3E0614B93C4F9E99B8383F6A1E4469DA50A", detail.DNSSec[0].Digest)
+	require.Equal(t, uint16(13), detail.DNSSec[1].Algorithm.ToUint16())
+
+	require.Equal(t, map[string][]string{
+		"ns1.example.com": {"192.0.2.1", "2001:db8::1"},
+		"ns2.example.com": {"192.0.2.2"},
+	}, detail.CNS)
+
+	var empty OrderDetail
+	require.NoError(t, json.Unmarshal([]byte(`{"orderid":"102","dnssec":[],"cns":{}}`), &empty))
+	require.Empty(t, empty.DNSSec)
+	require.Empty(t, empty.CNS)
+
+	require.Error(t, json.Unmarshal([]byte(`{"resellercost":"70000"}`), &empty))
+}
@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xpartacvs/go-resellerclub/core"
+)
+
+type RaaStatus string
+
+type VerificationStatus struct {
+	OrderID             string
+	DomainName          string
+	RegistrantContactID string
+	RegistrantEmail     string
+	Status              RaaStatus
+	StartTime           time.Time
+	Deadline            time.Time
+}
+
+const (
+	RaaVerified      RaaStatus = "Verified"
+	RaaPending       RaaStatus = "Pending"
+	RaaSuspended     RaaStatus = "Suspended"
+	RaaNotApplicable RaaStatus = "NA"
+
+	RaaVerificationPeriod = 15 * 24 * time.Hour
+
+	verificationPageSize = 100
+)
+
+func (s *VerificationStatus) Verified() bool {
+	return s.Status == RaaVerified || s.Status == RaaNotApplicable
+}
+
+func (s *VerificationStatus) Overdue(now time.Time) bool {
+	return !s.Verified() && !s.Deadline.IsZero() && !now.Before(s.Deadline)
+}
+
+func (d *domain) ResendVerification(orderID string) error {
+	data := make(url.Values)
+	data.Add("order-id", orderID)
+
+	resp, err := d.core.CallApi(http.MethodPost, "domains", "raa/resend-verification", data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bytesResp, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		errResponse := core.JSONStatusResponse{}
+		err = json.Unmarshal(bytesResp, &errResponse)
+		if err != nil {
+			return err
+		}
+		return errors.New(strings.ToLower(errResponse.Message))
+	}
+
+	boolResult, err := strconv.ParseBool(strings.Trim(string(bytesResp), `"`))
+	if err != nil {
+		return err
+	}
+	if !boolResult {
+		return core.ErrRcOperationFailed
+	}
+
+	return nil
+}
+
+func (d *domain) VerificationStatus(orderID string) (*VerificationStatus, error) {
+	detail, err := d.GetRegistrationOrderDetails(orderID, []string{"OrderDetails", "RegistrantContactDetails"})
+	if err != nil {
+		return nil, err
+	}
+	return detail.VerificationStatus(), nil
+}
+
+func (o *OrderDetail) VerificationStatus() *VerificationStatus {
+	status := &VerificationStatus{
+		OrderID:             o.OrderID,
+		DomainName:          o.DomainName,
+		RegistrantContactID: o.RegistrantContactID,
+		RegistrantEmail:     o.RegistrantContact.EmailAddr,
+		Status:              RaaStatus(o.RaaVerificationStatus),
+	}
+	start := o.RaaVerificationStartTime.ToTime()
+	if !start.IsZero() && start.Unix() > 0 {
+		status.StartTime = start
+		status.Deadline = start.Add(RaaVerificationPeriod)
+	}
+	return status
+}
+
+func UnverifiedRegistrants(d Domain, criteria OrderCriteria) ([]VerificationStatus, error) {
+	report := []VerificationStatus{}
+
+	for page, seen := uint16(1), 0; ; page++ {
+		result, err := d.SearchOrderSummaries(criteria, page, verificationPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, order := range result.Orders {
+			status, err := d.VerificationStatus(order.OrderID)
+			if err != nil {
+				return nil, err
+			}
+			if !status.Verified() {
+				report = append(report, *status)
+			}
+		}
+
+		seen += len(result.Orders)
+		if len(result.Orders) <= 0 || seen >= result.TotalMatched {
+			break
+		}
+	}
+
+	sort.SliceStable(report, func(i, j int) bool {
+		if report[i].Deadline.IsZero() || report[j].Deadline.IsZero() {
+			return !report[i].Deadline.IsZero()
+		}
+		return report[i].Deadline.Before(report[j].Deadline)
+	})
+	return report, nil
+}
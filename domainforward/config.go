@@ -0,0 +1,139 @@
+package domainforward
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var rgxSubDomainPrefix = regexp.MustCompile(`^([A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)(\.[A-Za-z0-9]([A-Za-z0-9-]{0,61}[A-Za-z0-9])?)*$`)
+
+func (c *ForwardingConfig) Validate() error {
+	if c == nil {
+		return errors.New("forwarding config must not nil")
+	}
+
+	if err := validator.New().Struct(c); err != nil {
+		return err
+	}
+
+	u, err := url.Parse(c.ForwardTo)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) <= 0 {
+		return fmt.Errorf("invalid forward url %q", c.ForwardTo)
+	}
+
+	if len(c.SubDomainPrefix) > 0 && !rgxSubDomainPrefix.MatchString(c.SubDomainPrefix) {
+		return fmt.Errorf("invalid sub domain prefix %q", c.SubDomainPrefix)
+	}
+
+	if !c.URLMasking && (len(c.MetaTags) > 0 || len(strings.TrimSpace(c.NoFrames)) > 0) {
+		return errors.New("meta tags and noframes require url masking")
+	}
+
+	return nil
+}
+
+func (c *ForwardingConfig) MetaTagsHTML() string {
+	tags := make([]string, 0, len(c.MetaTags))
+	for _, t := range c.MetaTags {
+		tags = append(tags, fmt.Sprintf(`<meta name="%s" content="%s">`, html.EscapeString(t.Name), html.EscapeString(t.Content)))
+	}
+	return strings.Join(tags, "\n")
+}
+
+func (c *ForwardingConfig) Matches(r *ForwardingRule) bool {
+	if r == nil {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSuffix(c.ForwardTo, "/"), strings.TrimSuffix(r.ForwardTo, "/")) &&
+		c.URLMasking == bool(r.UrlMasking) &&
+		strings.TrimSpace(c.MetaTagsHTML()) == strings.TrimSpace(r.MetaTags) &&
+		strings.TrimSpace(c.NoFrames) == strings.TrimSpace(r.NoFrames) &&
+		c.SubDomainForwarding == bool(r.SubdomainForwarding) &&
+		c.PathForwarding == bool(r.PathForwarding)
+}
+
+func (d *DetailsDomainForward) UnmarshalJSON(b []byte) error {
+	type details DetailsDomainForward
+	var top details
+	if err := json.Unmarshal(b, &top); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return err
+	}
+
+	top.SubDomains = map[string]*ForwardingRule{}
+	for key, raw := range fields {
+		raw = bytes.TrimSpace(raw)
+		if len(raw) <= 0 {
+			continue
+		}
+
+		switch raw[0] {
+		case '{':
+			var ruleFields map[string]json.RawMessage
+			if err := json.Unmarshal(raw, &ruleFields); err != nil {
+				return fmt.Errorf("decode sub domain %q: %w", key, err)
+			}
+			if _, ok := ruleFields["forward"]; !ok {
+				continue
+			}
+			rule := new(ForwardingRule)
+			if err := json.Unmarshal(raw, rule); err != nil {
+				return fmt.Errorf("decode sub domain %q: %w", key, err)
+			}
+			if len(rule.SubDomainPrefix) <= 0 {
+				rule.SubDomainPrefix = key
+			}
+			top.SubDomains[strings.ToLower(rule.SubDomainPrefix)] = rule
+		case '[':
+			var rules []*ForwardingRule
+			if err := json.Unmarshal(raw, &rules); err != nil {
+				return fmt.Errorf("decode sub domains %q: %w", key, err)
+			}
+			for _, rule := range rules {
+				if rule == nil || len(rule.SubDomainPrefix) <= 0 || len(rule.ForwardTo) <= 0 {
+					continue
+				}
+				top.SubDomains[strings.ToLower(rule.SubDomainPrefix)] = rule
+			}
+		}
+	}
+
+	*d = DetailsDomainForward(top)
+	return nil
+}
+
+func (d *DetailsDomainForward) IsActive() bool {
+	return len(d.ForwardTo) > 0 || len(d.SubDomains) > 0
+}
+
+func (d *DetailsDomainForward) Rule(subDomainPrefix string) *ForwardingRule {
+	if len(subDomainPrefix) > 0 {
+		return d.SubDomains[strings.ToLower(subDomainPrefix)]
+	}
+	if len(d.ForwardTo) <= 0 {
+		return nil
+	}
+	return &ForwardingRule{
+		ForwardTo:           d.ForwardTo,
+		UrlMasking:          d.UrlMasking,
+		MetaTags:            d.MetaTags,
+		NoFrames:            d.NoFrames,
+		PathForwarding:      d.PathForwarding,
+		SubdomainForwarding: d.SubdomainForwarding,
+	}
+}
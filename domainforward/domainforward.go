@@ -19,6 +19,10 @@ type DomainForward interface {
 	GettingDNSRecords(domainName string) ([]*DNSRecord, error)
 	RemoveDomainForwardingForDomain(domainName string) (bool, error)
 	DisableDomainForwardingForSubDomain(orderID, subDomainPrefix string) (bool, error)
+	Activate(orderID string, config *ForwardingConfig) (*StdResponse, error)
+	Manage(orderID string, config *ForwardingConfig) (*StdResponse, error)
+	Plan(orderID string, config *ForwardingConfig) (ApplyAction, error)
+	Apply(orderID string, config *ForwardingConfig) (*ApplyResult, error)
 }
 
 func New(c core.Core) DomainForward {
@@ -242,3 +246,56 @@ func (d *domainForward) DisableDomainForwardingForSubDomain(orderID, subDomainPr
 
 	return result, nil
 }
+
+func (d *domainForward) Activate(orderID string, config *ForwardingConfig) (*StdResponse, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return d.ActivatingDomainForwardingService(orderID, config.SubDomainPrefix, config.ForwardTo, config.URLMasking, config.MetaTagsHTML(), config.NoFrames, config.SubDomainForwarding, config.PathForwarding)
+}
+
+func (d *domainForward) Manage(orderID string, config *ForwardingConfig) (*StdResponse, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return d.ManagingDomainForwardingService(orderID, config.SubDomainPrefix, config.ForwardTo, config.URLMasking, config.MetaTagsHTML(), config.NoFrames, config.SubDomainForwarding, config.PathForwarding)
+}
+
+func (d *domainForward) Plan(orderID string, config *ForwardingConfig) (ApplyAction, error) {
+	if err := config.Validate(); err != nil {
+		return "", err
+	}
+
+	details, err := d.GettingDetailsDomainForwardingService(orderID, len(config.SubDomainPrefix) > 0)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case !details.IsActive():
+		return ApplyActivate, nil
+	case config.Matches(details.Rule(config.SubDomainPrefix)):
+		return ApplyNone, nil
+	}
+	return ApplyManage, nil
+}
+
+func (d *domainForward) Apply(orderID string, config *ForwardingConfig) (*ApplyResult, error) {
+	action, err := d.Plan(orderID, config)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ApplyResult{Action: action}
+	switch action {
+	case ApplyActivate:
+		result.Response, err = d.Activate(orderID, config)
+	case ApplyManage:
+		result.Response, err = d.Manage(orderID, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,249 @@
+package domainforward
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/xpartacvs/go-resellerclub/dns"
+	"github.com/xpartacvs/go-resellerclub/domain"
+)
+
+type fakeDomain struct {
+	domain.Domain
+	orders map[string]string
+}
+
+func (f *fakeDomain) GetOrderID(domainName string) (string, error) {
+	if id, ok := f.orders[domainName]; ok {
+		return id, nil
+	}
+	return "", errors.New("domain not found")
+}
+
+type fakeForward struct {
+	DomainForward
+	applied []string
+	records []*DNSRecord
+}
+
+func (f *fakeForward) GettingDNSRecords(domainName string) ([]*DNSRecord, error) {
+	return f.records, nil
+}
+
+func (f *fakeForward) Plan(orderID string, config *ForwardingConfig) (ApplyAction, error) {
+	if err := config.Validate(); err != nil {
+		return "", err
+	}
+	return ApplyActivate, nil
+}
+
+func (f *fakeForward) Apply(orderID string, config *ForwardingConfig) (*ApplyResult, error) {
+	action, err := f.Plan(orderID, config)
+	if err != nil {
+		return nil, err
+	}
+	f.applied = append(f.applied, orderID+":"+config.SubDomainPrefix)
+	return &ApplyResult{Action: action}, nil
+}
+
+type fakeCore struct {
+	details string
+	calls   []string
+	data    []url.Values
+}
+
+func (f *fakeCore) CallApi(method, namespace, apiName string, data url.Values) (*http.Response, error) {
+	f.calls = append(f.calls, apiName)
+	f.data = append(f.data, data)
+	body := `{"status":"Success","message":"ok"}`
+	if apiName == "details" {
+		body = f.details
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (f *fakeCore) IsProduction() bool {
+	return false
+}
+
+func TestPlanApply(t *testing.T) {
+	config := &ForwardingConfig{ForwardTo: "https://example.net", PathForwarding: true}
+
+	c := &fakeCore{details: `{}`}
+	result, err := New(c).Apply("1", config)
+	require.NoError(t, err)
+	require.Equal(t, ApplyActivate, result.Action)
+	require.Equal(t, "Success", result.Response.Status)
+	require.Equal(t, []string{"details", "activate"}, c.calls)
+	require.Equal(t, "https://example.net", c.data[1].Get("forward-to"))
+	require.Equal(t, "true", c.data[1].Get("path-forwarding"))
+
+	c = &fakeCore{details: `{"forward":"https://example.org","urlmasking":"false","pathforwarding":"true","subdomainforwarding":"false"}`}
+	result, err = New(c).Apply("1", config)
+	require.NoError(t, err)
+	require.Equal(t, ApplyManage, result.Action)
+	require.Equal(t, []string{"details", "manage"}, c.calls)
+
+	c = &fakeCore{details: `{"forward":"https://example.net/","urlmasking":"false","pathforwarding":"true","subdomainforwarding":"false"}`}
+	result, err = New(c).Apply("1", config)
+	require.NoError(t, err)
+	require.Equal(t, ApplyNone, result.Action)
+	require.Nil(t, result.Response)
+	require.Equal(t, []string{"details"}, c.calls)
+
+	c = &fakeCore{details: `{}`}
+	_, err = New(c).Apply("1", &ForwardingConfig{ForwardTo: "not-a-url"})
+	require.Error(t, err)
+	require.Empty(t, c.calls)
+}
+
+func TestDetailsDomainForwardDecode(t *testing.T) {
+	raw := `{
+		"forward": "https://example.net/",
+		"urlmasking": "false",
+		"pathforwarding": "true",
+		"subdomainforwarding": "false",
+		"ipaddress": "192.0.2.1",
+		"domainname": "example.com",
+		"blog": {"forward": "https://blog.example.net", "urlmasking": "true", "pathforwarding": "false", "subdomainforwarding": "false", "noframes": "<p>blog</p>"}
+	}`
+
+	var details DetailsDomainForward
+	require.NoError(t, json.Unmarshal([]byte(raw), &details))
+	require.Equal(t, "https://example.net/", details.ForwardTo)
+	require.True(t, details.IsActive())
+	require.Len(t, details.SubDomains, 1)
+	require.Equal(t, "blog", details.Rule("BLOG").SubDomainPrefix)
+
+	root := &ForwardingConfig{ForwardTo: "https://example.net", PathForwarding: true}
+	require.NoError(t, root.Validate())
+	require.True(t, root.Matches(details.Rule("")))
+
+	blog := &ForwardingConfig{SubDomainPrefix: "blog", ForwardTo: "https://blog.example.net", URLMasking: true, NoFrames: "<p>blog</p>"}
+	require.True(t, blog.Matches(details.Rule("blog")))
+	blog.MetaTags = []MetaTag{{Name: "description", Content: `"Blog"`}}
+	require.False(t, blog.Matches(details.Rule("blog")))
+	require.Equal(t, `<meta name="description" content="&#34;Blog&#34;">`, blog.MetaTagsHTML())
+
+	raw = `{"forward": "https://example.net/", "extra": {"foo": "bar"}, "rules": [{"subdomainprefix": "shop", "forward": "https://shop.example.net"}]}`
+	require.NoError(t, json.Unmarshal([]byte(raw), &details))
+	require.Len(t, details.SubDomains, 1)
+	require.Equal(t, "https://shop.example.net", details.Rule("shop").ForwardTo)
+
+	require.Error(t, json.Unmarshal([]byte(`{"forward": "https://example.net/", "rules": [{"subdomainprefix": 1}]}`), &details))
+	require.Error(t, json.Unmarshal([]byte(`{"blog": {"forward": "https://blog.example.net", "urlmasking": "maybe"}}`), &details))
+}
+
+func TestForwardingConfigValidate(t *testing.T) {
+	require.Error(t, (&ForwardingConfig{ForwardTo: "ftp://example.net"}).Validate())
+	require.Error(t, (&ForwardingConfig{ForwardTo: "https://example.net", SubDomainPrefix: "-bad"}).Validate())
+	require.Error(t, (&ForwardingConfig{ForwardTo: "https://example.net", NoFrames: "<p>x</p>"}).Validate())
+	require.NoError(t, (&ForwardingConfig{ForwardTo: "https://example.net", SubDomainPrefix: "shop.eu", URLMasking: true, NoFrames: "<p>x</p>"}).Validate())
+}
+
+func TestImporter(t *testing.T) {
+	csv := strings.Join([]string{
+		"domain,subdomain,target,masking",
+		"Example.com,,https://example.net,yes",
+		"example.com,blog,https://blog.example.net,false",
+		"missing.com,,https://example.net,",
+		"example.org,,not-a-url,no",
+		"example.com,,https://example.net,maybe",
+		"example.com,https://example.net",
+		",,https://example.net,yes",
+		"example.org,shop,https://shop.example.org,no",
+	}, "\n")
+
+	rows, err := ParseImportCSV(strings.NewReader(csv))
+	require.NoError(t, err)
+	require.Len(t, rows, 8)
+	require.Equal(t, ImportRow{Record: 2, DomainName: "example.com", ForwardTo: "https://example.net", URLMasking: true}, rows[0])
+	require.EqualError(t, rows[4].Err, `record 6: invalid masking flag "maybe"`)
+	require.EqualError(t, rows[5].Err, "record 7: expected 4 columns, got 2")
+	require.EqualError(t, rows[6].Err, "record 8: domain must not empty")
+	require.NoError(t, rows[7].Err)
+
+	d := &fakeDomain{orders: map[string]string{"example.com": "1", "example.org": "2"}}
+
+	forward := &fakeForward{}
+	results := NewImporter(forward, d, true).ImportRows(rows)
+	require.Empty(t, forward.applied)
+	require.True(t, results[0].Success)
+	require.True(t, results[0].DryRun)
+	require.Equal(t, ApplyActivate, results[1].Action)
+	require.False(t, results[2].Success)
+	require.False(t, results[3].Success)
+
+	require.False(t, results[4].Success)
+	require.Equal(t, rows[4].Err, results[4].Err)
+	require.Empty(t, results[4].OrderID)
+	require.True(t, results[7].Success)
+
+	results = NewImporter(forward, d, false).ImportRows(rows)
+	require.Equal(t, []string{"1:", "1:blog", "2:shop"}, forward.applied)
+	require.Equal(t, "1", results[1].OrderID)
+}
+
+type fakeZone struct {
+	dns.DNS
+	records []*dns.DNSRecord
+	added   []string
+}
+
+func (f *fakeZone) SearchingDNSRecords(domainName string, typeRecord dns.RecordType, noOfRecords, pageNo int, host, value string) (*dns.SearchingDNSRecords, error) {
+	result := &dns.SearchingDNSRecords{Recsindb: "0", Records: []*dns.DNSRecord{}}
+	if pageNo > 1 {
+		return result, nil
+	}
+	for _, r := range f.records {
+		if r.Type == string(typeRecord) {
+			result.Records = append(result.Records, r)
+		}
+	}
+	result.Recsindb = strconv.Itoa(len(result.Records))
+	return result, nil
+}
+
+func (f *fakeZone) AddingIPv4AddressRecord(domainName, value, host string, ttl int) (*dns.StdResponse, error) {
+	f.added = append(f.added, "A "+host+" "+value)
+	return &dns.StdResponse{Status: "Success"}, nil
+}
+
+func (f *fakeZone) AddingCNAMERecord(domainName, value, host string, ttl int) (*dns.StdResponse, error) {
+	f.added = append(f.added, "CNAME "+host+" "+value)
+	return &dns.StdResponse{Status: "Success"}, nil
+}
+
+func TestReconciler(t *testing.T) {
+	forward := &fakeForward{records: []*DNSRecord{
+		{Type: "A", Host: "example.com", Value: "192.0.2.1"},
+		{Type: "CNAME", Host: "www.example.com", Value: "example.com"},
+		{Type: "A", Host: "shop.example.com", Value: "192.0.2.1"},
+	}}
+	zone := &fakeZone{records: []*dns.DNSRecord{
+		{Type: "A", Host: "example.com", Value: "192.0.2.1", TimeToLive: "14400"},
+		{Type: "A", Host: "shop.example.com", Value: "198.51.100.7", TimeToLive: "14400"},
+	}}
+
+	report, err := NewReconciler(forward, zone).Check("example.com")
+	require.NoError(t, err)
+	require.False(t, report.InSync())
+	require.Equal(t, RecordInSync, report.Entries[0].Status)
+	require.Equal(t, RecordMissing, report.Entries[1].Status)
+	require.Equal(t, RecordMismatch, report.Entries[2].Status)
+	require.Equal(t, []string{"198.51.100.7"}, report.Entries[2].Actual)
+	require.Empty(t, zone.added)
+
+	report, err = NewReconciler(forward, zone).Fix("example.com")
+	require.NoError(t, err)
+	require.True(t, report.Entries[1].Fixed)
+	require.False(t, report.Entries[2].Fixed)
+	require.Equal(t, []string{"CNAME www example.com"}, zone.added)
+}
@@ -0,0 +1,182 @@
+package domainforward
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/xpartacvs/go-resellerclub/domain"
+)
+
+type ImportRow struct {
+	Record          int
+	DomainName      string
+	SubDomainPrefix string
+	ForwardTo       string
+	URLMasking      bool
+	Err             error
+}
+
+type ImportResult struct {
+	Row     ImportRow
+	OrderID string
+	Action  ApplyAction
+	DryRun  bool
+	Success bool
+	Err     error
+}
+
+type Importer interface {
+	Import(r io.Reader) ([]ImportResult, error)
+	ImportRows(rows []ImportRow) []ImportResult
+}
+
+type importer struct {
+	forward DomainForward
+	domain  domain.Domain
+	dryRun  bool
+}
+
+const importColumns = 4
+
+func NewImporter(df DomainForward, d domain.Domain, dryRun bool) Importer {
+	return &importer{
+		forward: df,
+		domain:  d,
+		dryRun:  dryRun,
+	}
+}
+
+func ParseImportCSV(r io.Reader) ([]ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows := []ImportRow{}
+	record := 0
+	for {
+		fields, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		record++
+		if perr, ok := err.(*csv.ParseError); ok {
+			rows = append(rows, ImportRow{Record: record, Err: fmt.Errorf("record %d: %w", record, perr.Err)})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(rows) <= 0 && isImportHeader(fields) {
+			continue
+		}
+		if len(fields) == 1 && len(strings.TrimSpace(fields[0])) <= 0 {
+			continue
+		}
+
+		row := ImportRow{Record: record}
+		if len(fields) > 0 {
+			row.DomainName = strings.ToLower(strings.TrimSpace(fields[0]))
+		}
+		switch {
+		case len(fields) < importColumns-1 || len(fields) > importColumns:
+			row.Err = fmt.Errorf("record %d: expected %d columns, got %d", record, importColumns, len(fields))
+		case len(row.DomainName) <= 0:
+			row.Err = fmt.Errorf("record %d: domain must not empty", record)
+		default:
+			row.SubDomainPrefix = strings.ToLower(strings.TrimSpace(fields[1]))
+			row.ForwardTo = strings.TrimSpace(fields[2])
+			if len(fields) == importColumns && len(strings.TrimSpace(fields[3])) > 0 {
+				if row.URLMasking, err = parseImportBool(fields[3]); err != nil {
+					row.Err = fmt.Errorf("record %d: %w", record, err)
+				}
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func (i *importer) Import(r io.Reader) ([]ImportResult, error) {
+	rows, err := ParseImportCSV(r)
+	if err != nil {
+		return nil, err
+	}
+	return i.ImportRows(rows), nil
+}
+
+func (i *importer) ImportRows(rows []ImportRow) []ImportResult {
+	orderIDs := map[string]string{}
+	results := make([]ImportResult, 0, len(rows))
+	for _, row := range rows {
+		result := ImportResult{Row: row, DryRun: i.dryRun, Err: row.Err}
+		if result.Err == nil {
+			result.OrderID, result.Err = i.orderID(orderIDs, row.DomainName)
+		}
+		if result.Err == nil {
+			result.Action, result.Err = i.apply(result.OrderID, row)
+		}
+		result.Success = result.Err == nil
+		results = append(results, result)
+	}
+	return results
+}
+
+func (i *importer) orderID(cache map[string]string, domainName string) (string, error) {
+	if orderID, ok := cache[domainName]; ok {
+		return orderID, nil
+	}
+	if i.domain == nil {
+		return "", errors.New("domain client must not nil")
+	}
+
+	orderID, err := i.domain.GetOrderID(domainName)
+	if err != nil {
+		return "", err
+	}
+	cache[domainName] = orderID
+	return orderID, nil
+}
+
+func (i *importer) apply(orderID string, row ImportRow) (ApplyAction, error) {
+	config := &ForwardingConfig{
+		SubDomainPrefix: row.SubDomainPrefix,
+		ForwardTo:       row.ForwardTo,
+		URLMasking:      row.URLMasking,
+	}
+
+	if i.dryRun {
+		return i.forward.Plan(orderID, config)
+	}
+
+	result, err := i.forward.Apply(orderID, config)
+	if err != nil {
+		return "", err
+	}
+	return result.Action, nil
+}
+
+func isImportHeader(fields []string) bool {
+	return len(fields) > 0 && strings.EqualFold(strings.TrimSpace(fields[0]), "domain")
+}
+
+func parseImportBool(value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "y", "yes", "on":
+		return true, nil
+	case "n", "no", "off":
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		return false, fmt.Errorf("invalid masking flag %q", value)
+	}
+	return b, nil
+}
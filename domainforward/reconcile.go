@@ -0,0 +1,153 @@
+package domainforward
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/xpartacvs/go-resellerclub/dns"
+)
+
+type RecordStatus string
+
+type ReconcileEntry struct {
+	Expected *dns.Record
+	Status   RecordStatus
+	Actual   []string
+	Fixed    bool
+	Err      error
+}
+
+type ReconcileReport struct {
+	DomainName string
+	Entries    []*ReconcileEntry
+}
+
+type Reconciler interface {
+	Check(domainName string) (*ReconcileReport, error)
+	Fix(domainName string) (*ReconcileReport, error)
+}
+
+type reconciler struct {
+	forward DomainForward
+	dns     dns.DNS
+}
+
+const (
+	RecordInSync   RecordStatus = "in-sync"
+	RecordMissing  RecordStatus = "missing"
+	RecordMismatch RecordStatus = "mismatch"
+)
+
+func NewReconciler(df DomainForward, d dns.DNS) Reconciler {
+	return &reconciler{
+		forward: df,
+		dns:     d,
+	}
+}
+
+func (r *ReconcileReport) InSync() bool {
+	for _, e := range r.Entries {
+		if e.Status != RecordInSync || e.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *reconciler) Check(domainName string) (*ReconcileReport, error) {
+	if r.forward == nil || r.dns == nil {
+		return nil, errors.New("domain forward and dns clients must not nil")
+	}
+
+	domainName = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(domainName), "."))
+	expected, err := r.forward.GettingDNSRecords(domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconcileReport{
+		DomainName: domainName,
+		Entries:    make([]*ReconcileEntry, 0, len(expected)),
+	}
+	for _, e := range expected {
+		report.Entries = append(report.Entries, r.checkRecord(domainName, e))
+	}
+	return report, nil
+}
+
+func (r *reconciler) Fix(domainName string) (*ReconcileReport, error) {
+	report, err := r.Check(domainName)
+	if err != nil {
+		return nil, err
+	}
+
+	batch := dns.NewBatch(r.dns, 1)
+	missing := []*ReconcileEntry{}
+	for _, e := range report.Entries {
+		if e.Status != RecordMissing || e.Err != nil {
+			continue
+		}
+		if e.Expected.Type != dns.RecordA && e.Expected.Type != dns.RecordCNAME {
+			continue
+		}
+		batch.Add(report.DomainName, e.Expected)
+		missing = append(missing, e)
+	}
+
+	for i, result := range batch.Execute() {
+		missing[i].Fixed = result.Success
+		if !result.Success {
+			missing[i].Err = result.Err
+			if missing[i].Err == nil {
+				missing[i].Err = errors.New(strings.ToLower(result.Message))
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (r *reconciler) checkRecord(domainName string, expected *DNSRecord) *ReconcileEntry {
+	entry := &ReconcileEntry{
+		Expected: &dns.Record{
+			Type:  dns.RecordType(strings.ToUpper(strings.TrimSpace(expected.Type))),
+			Host:  dns.RelativeHost(domainName, expected.Host),
+			Value: strings.TrimSpace(expected.Value),
+			TTL:   expected.TimeToLive.ToInt(),
+		},
+		Actual: []string{},
+	}
+	if entry.Expected.TTL <= 0 {
+		entry.Expected.TTL = dns.DefaultTTL
+	}
+
+	actual, err := dns.FindRecords(r.dns, domainName, entry.Expected.Type, entry.Expected.Host)
+	if err != nil {
+		entry.Err = fmt.Errorf("search %s records: %w", entry.Expected.Type, err)
+		return entry
+	}
+
+	entry.Status = RecordMissing
+	for _, a := range actual {
+		entry.Actual = append(entry.Actual, a.Value)
+		entry.Status = RecordMismatch
+	}
+	for _, a := range actual {
+		if sameRecordValue(entry.Expected, a) {
+			entry.Status = RecordInSync
+			break
+		}
+	}
+	return entry
+}
+
+func sameRecordValue(expected, actual *dns.Record) bool {
+	switch expected.Type {
+	case dns.RecordA, dns.RecordAAAA:
+		ip := net.ParseIP(expected.Value)
+		return ip != nil && ip.Equal(net.ParseIP(actual.Value))
+	}
+	return strings.EqualFold(strings.TrimSuffix(expected.Value, "."), strings.TrimSuffix(actual.Value, "."))
+}
@@ -2,17 +2,53 @@ package domainforward
 
 import "github.com/xpartacvs/go-resellerclub/core"
 
+type ApplyAction string
+
 type StdResponse struct {
 	Status  string `json:"status"`
 	Message string `json:"message"`
 }
 
 type DetailsDomainForward struct {
+	ForwardTo           string                     `json:"forward"`
+	UrlMasking          core.JSONBool              `json:"urlmasking"`
+	MetaTags            string                     `json:"metatags"`
+	NoFrames            string                     `json:"noframes"`
+	PathForwarding      core.JSONBool              `json:"pathforwarding"`
+	SubdomainForwarding core.JSONBool              `json:"subdomainforwarding"`
+	IpAddress           string                     `json:"ipaddress"`
+	DomainName          string                     `json:"domainname"`
+	SubDomains          map[string]*ForwardingRule `json:"-"`
+}
+
+type ForwardingRule struct {
+	SubDomainPrefix     string        `json:"subdomainprefix"`
+	ForwardTo           string        `json:"forward"`
 	UrlMasking          core.JSONBool `json:"urlmasking"`
+	MetaTags            string        `json:"metatags"`
+	NoFrames            string        `json:"noframes"`
 	PathForwarding      core.JSONBool `json:"pathforwarding"`
 	SubdomainForwarding core.JSONBool `json:"subdomainforwarding"`
-	IpAddress           string        `json:"ipaddress"`
-	DomainName          string        `json:"domainname"`
+}
+
+type MetaTag struct {
+	Name    string `json:"name" validate:"required"`
+	Content string `json:"content"`
+}
+
+type ForwardingConfig struct {
+	SubDomainPrefix     string    `json:"subdomainprefix"`
+	ForwardTo           string    `json:"forwardto" validate:"required,url"`
+	URLMasking          bool      `json:"urlmasking"`
+	MetaTags            []MetaTag `json:"metatags,omitempty" validate:"dive"`
+	NoFrames            string    `json:"noframes,omitempty"`
+	SubDomainForwarding bool      `json:"subdomainforwarding"`
+	PathForwarding      bool      `json:"pathforwarding"`
+}
+
+type ApplyResult struct {
+	Action   ApplyAction
+	Response *StdResponse
 }
 
 type DNSRecord struct {
@@ -21,3 +57,9 @@ type DNSRecord struct {
 	Host       string       `json:"host"`
 	Value      string       `json:"value"`
 }
+
+const (
+	ApplyActivate ApplyAction = "activate"
+	ApplyManage   ApplyAction = "manage"
+	ApplyNone     ApplyAction = "none"
+)
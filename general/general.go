@@ -1,6 +1,8 @@
 package general
 
 import (
+	"sync"
+
 	"github.com/xpartacvs/go-resellerclub/core"
 )
 
@@ -8,12 +10,15 @@ type general struct {
 	core       core.Core
 	currencies currencyDB
 	countries  countryDB
+	states     map[CountryISO]States
+	mutex      sync.RWMutex
 }
 
 type General interface {
 	CurrencyOf(iso CurrencyISO) Currency
 	CountryName(iso CountryISO) string
 	StatesOf(iso CountryISO) (States, error)
+	ResolveState(iso CountryISO, state string) (*StateResolution, error)
 }
 
 func (g *general) CountryName(iso CountryISO) string {
@@ -25,7 +30,34 @@ func (g *general) CurrencyOf(iso CurrencyISO) Currency {
 }
 
 func (g *general) StatesOf(iso CountryISO) (States, error) {
-	return fetchStateList(g.core, iso)
+	g.mutex.RLock()
+	states, ok := g.states[iso]
+	g.mutex.RUnlock()
+	if ok {
+		return states, nil
+	}
+
+	states, err := fetchStateList(g.core, iso)
+	if err != nil {
+		return nil, err
+	}
+
+	g.mutex.Lock()
+	if g.states == nil {
+		g.states = map[CountryISO]States{}
+	}
+	g.states[iso] = states
+	g.mutex.Unlock()
+
+	return states, nil
+}
+
+func (g *general) ResolveState(iso CountryISO, state string) (*StateResolution, error) {
+	states, err := g.StatesOf(iso)
+	if err != nil {
+		return nil, err
+	}
+	return ResolveState(states, state), nil
 }
 
 func New(c core.Core) (General, error) {
@@ -41,5 +73,6 @@ func New(c core.Core) (General, error) {
 		core:       c,
 		currencies: curr,
 		countries:  cntrs,
+		states:     map[CountryISO]States{},
 	}, nil
 }
@@ -0,0 +1,112 @@
+package general
+
+import (
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePhone(t *testing.T) {
+	cases := []struct {
+		raw         string
+		country     CountryISO
+		callingCode string
+		subscriber  string
+		iso         CountryISO
+	}{
+		{"+1 (217) 555-0100", "", "1", "2175550100", "US"},
+		{"1-217-555-0100", "US", "1", "2175550100", "US"},
+		{"+1 416 555 0100", "CA", "1", "4165550100", "CA"},
+		{"0812-3456-7890", "id", "62", "81234567890", "ID"},
+		{"+62 (0) 812 3456 7890", "", "62", "81234567890", "ID"},
+		{"0049 30 1234567", "", "49", "301234567", "DE"},
+		{"06 12 34 56 78", "FR", "33", "612345678", "FR"},
+		{"8 (495) 123-45-67", "RU", "7", "4951234567", "RU"},
+		{"+39 06 1234 5678", "", "39", "0612345678", "IT"},
+		{"+44 20 7946 0958", "", "44", "2079460958", "GB"},
+		{"+370 612 34567", "LT", "370", "61234567", "LT"},
+		{"612 34567", "lt", "370", "61234567", "LT"},
+		{"+370 612 34567", "", "370", "61234567", "LT"},
+		{"+358 40 123 4567", "", "358", "401234567", "FI"},
+		{"+1 876 555 0100", "JM", "1", "8765550100", "JM"},
+	}
+	for _, c := range cases {
+		phone, err := ParsePhone(c.raw, c.country)
+		require.NoError(t, err, c.raw)
+		require.Equal(t, c.callingCode, phone.CallingCode, c.raw)
+		require.Equal(t, c.subscriber, phone.Subscriber, c.raw)
+		require.Equal(t, c.iso, phone.Country, c.raw)
+	}
+
+	_, err := ParsePhone("555-0100", "US")
+	require.Error(t, err)
+	_, err = ParsePhone("555 CALL NOW", "US")
+	require.Equal(t, ErrPhoneInvalid, err)
+	_, err = ParsePhone("2175550100", "")
+	require.Equal(t, ErrPhoneUnknownCountry, err)
+
+	phone, err := NormalizePhone("+62", "0812 3456 7890", "ID")
+	require.NoError(t, err)
+	require.Equal(t, "+6281234567890", phone.E164())
+
+	phone, err = NormalizePhone("370", "(612) 34-567", "LT")
+	require.NoError(t, err)
+	require.Equal(t, "+37061234567", phone.E164())
+
+	phone, err = NormalizePhone("383", "44 123 456", "XK")
+	require.NoError(t, err)
+	require.Equal(t, "383", phone.CallingCode)
+	require.Equal(t, "44123456", phone.Subscriber)
+
+	_, err = ParsePhone("44 123 456", "XK")
+	require.Equal(t, ErrPhoneUnknownCountry, err)
+}
+
+type fakeCore struct {
+	calls int
+}
+
+func (f *fakeCore) CallApi(method, namespace, apiName string, data url.Values) (*http.Response, error) {
+	f.calls++
+	body := `{}`
+	if data.Get("country-code") == "US" {
+		body = `{"Alabama":"AL","New York":"NY"}`
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func (f *fakeCore) IsProduction() bool {
+	return false
+}
+
+func TestResolveState(t *testing.T) {
+	c := &fakeCore{}
+	g := &general{core: c}
+
+	res, err := g.ResolveState("US", "ny")
+	require.NoError(t, err)
+	require.True(t, res.Matched)
+	require.Equal(t, "New York", res.State)
+
+	res, err = g.ResolveState("US", " alabama ")
+	require.NoError(t, err)
+	require.Equal(t, "Alabama", res.State)
+	require.Equal(t, 1, c.calls)
+
+	res, err = g.ResolveState("US", "Bavaria")
+	require.NoError(t, err)
+	require.True(t, res.IsOther())
+	require.Equal(t, OtherState, res.State)
+	require.Equal(t, "Bavaria", res.OtherState)
+
+	res, err = g.ResolveState("SG", "Central")
+	require.NoError(t, err)
+	require.False(t, res.Matched)
+	require.False(t, res.IsOther())
+	require.Equal(t, "Central", res.State)
+	require.Equal(t, 2, c.calls)
+}
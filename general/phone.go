@@ -0,0 +1,290 @@
+package general
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type PhoneNumber struct {
+	Country     CountryISO
+	CallingCode string
+	Subscriber  string
+}
+
+type phoneRule struct {
+	CallingCode string
+	MinLength   int
+	MaxLength   int
+	TrunkPrefix string
+}
+
+var (
+	ErrPhoneInvalid        = errors.New("invalid phone number")
+	ErrPhoneUnknownCountry = errors.New("unable to determine country calling code")
+
+	phoneRules = map[CountryISO]phoneRule{
+		"AE": {"971", 8, 9, "0"},
+		"AR": {"54", 10, 10, "0"},
+		"AT": {"43", 4, 13, "0"},
+		"AU": {"61", 9, 9, "0"},
+		"BD": {"880", 10, 10, "0"},
+		"BE": {"32", 8, 9, "0"},
+		"BR": {"55", 10, 11, "0"},
+		"CA": {"1", 10, 10, "1"},
+		"CH": {"41", 9, 9, "0"},
+		"CL": {"56", 9, 9, ""},
+		"CN": {"86", 5, 12, "0"},
+		"CO": {"57", 8, 10, ""},
+		"CZ": {"420", 9, 9, ""},
+		"DE": {"49", 6, 13, "0"},
+		"DK": {"45", 8, 8, ""},
+		"EG": {"20", 9, 10, "0"},
+		"ES": {"34", 9, 9, ""},
+		"FI": {"358", 5, 12, "0"},
+		"FR": {"33", 9, 9, "0"},
+		"GB": {"44", 9, 10, "0"},
+		"GR": {"30", 10, 10, ""},
+		"HK": {"852", 8, 8, ""},
+		"HU": {"36", 8, 9, "06"},
+		"ID": {"62", 7, 12, "0"},
+		"IE": {"353", 7, 9, "0"},
+		"IL": {"972", 8, 9, "0"},
+		"IN": {"91", 10, 10, "0"},
+		"IS": {"354", 7, 7, ""},
+		"IT": {"39", 6, 11, ""},
+		"JP": {"81", 9, 10, "0"},
+		"KE": {"254", 9, 9, "0"},
+		"KR": {"82", 8, 10, "0"},
+		"KZ": {"7", 10, 10, "8"},
+		"LU": {"352", 4, 11, ""},
+		"MX": {"52", 10, 10, ""},
+		"MY": {"60", 8, 10, "0"},
+		"NG": {"234", 8, 10, "0"},
+		"NL": {"31", 9, 9, "0"},
+		"NO": {"47", 8, 8, ""},
+		"NZ": {"64", 8, 10, "0"},
+		"PE": {"51", 8, 9, "0"},
+		"PH": {"63", 8, 10, "0"},
+		"PK": {"92", 9, 10, "0"},
+		"PL": {"48", 9, 9, ""},
+		"PT": {"351", 9, 9, ""},
+		"RO": {"40", 9, 9, "0"},
+		"RU": {"7", 10, 10, "8"},
+		"SA": {"966", 9, 9, "0"},
+		"SE": {"46", 7, 10, "0"},
+		"SG": {"65", 8, 8, ""},
+		"TH": {"66", 8, 9, "0"},
+		"TR": {"90", 10, 10, "0"},
+		"TW": {"886", 8, 9, "0"},
+		"UA": {"380", 9, 9, "0"},
+		"US": {"1", 10, 10, "1"},
+		"VN": {"84", 9, 10, "0"},
+		"ZA": {"27", 9, 9, "0"},
+	}
+
+	callingCodes = map[CountryISO]string{
+		"AD": "376", "AF": "93", "AG": "1", "AI": "1", "AL": "355", "AM": "374",
+		"AO": "244", "AS": "1", "AW": "297", "AX": "358", "AZ": "994", "BA": "387",
+		"BB": "1", "BF": "226", "BG": "359", "BH": "973", "BI": "257", "BJ": "229",
+		"BL": "590", "BM": "1", "BN": "673", "BO": "591", "BQ": "599", "BS": "1",
+		"BT": "975", "BW": "267", "BY": "375", "BZ": "501", "CC": "61", "CD": "243",
+		"CF": "236", "CG": "242", "CI": "225", "CK": "682", "CM": "237", "CR": "506",
+		"CU": "53", "CV": "238", "CW": "599", "CX": "61", "CY": "357", "DJ": "253",
+		"DM": "1", "DO": "1", "DZ": "213", "EC": "593", "EE": "372", "EH": "212",
+		"ER": "291", "ET": "251", "FJ": "679", "FK": "500", "FM": "691", "FO": "298",
+		"GA": "241", "GD": "1", "GE": "995", "GF": "594", "GG": "44", "GH": "233",
+		"GI": "350", "GL": "299", "GM": "220", "GN": "224", "GP": "590", "GQ": "240",
+		"GT": "502", "GU": "1", "GW": "245", "GY": "592", "HN": "504", "HR": "385",
+		"HT": "509", "IM": "44", "IO": "246", "IQ": "964", "IR": "98", "JE": "44",
+		"JM": "1", "JO": "962", "KG": "996", "KH": "855", "KI": "686", "KM": "269",
+		"KN": "1", "KP": "850", "KW": "965", "KY": "1", "LA": "856", "LB": "961",
+		"LC": "1", "LI": "423", "LK": "94", "LR": "231", "LS": "266", "LT": "370",
+		"LV": "371", "LY": "218", "MA": "212", "MC": "377", "MD": "373", "ME": "382",
+		"MF": "590", "MG": "261", "MH": "692", "MK": "389", "ML": "223", "MM": "95",
+		"MN": "976", "MO": "853", "MP": "1", "MQ": "596", "MR": "222", "MS": "1",
+		"MT": "356", "MU": "230", "MV": "960", "MW": "265", "MZ": "258", "NA": "264",
+		"NC": "687", "NE": "227", "NF": "672", "NI": "505", "NP": "977", "NR": "674",
+		"NU": "683", "OM": "968", "PA": "507", "PF": "689", "PG": "675", "PM": "508",
+		"PR": "1", "PS": "970", "PW": "680", "PY": "595", "QA": "974", "RE": "262",
+		"RS": "381", "RW": "250", "SB": "677", "SC": "248", "SD": "249", "SH": "290",
+		"SI": "386", "SJ": "47", "SK": "421", "SL": "232", "SM": "378", "SN": "221",
+		"SO": "252", "SR": "597", "SS": "211", "ST": "239", "SV": "503", "SX": "1",
+		"SY": "963", "SZ": "268", "TC": "1", "TD": "235", "TG": "228", "TJ": "992",
+		"TK": "690", "TL": "670", "TM": "993", "TN": "216", "TO": "676", "TT": "1",
+		"TV": "688", "TZ": "255", "UG": "256", "UY": "598", "UZ": "998", "VA": "39",
+		"VC": "1", "VE": "58", "VG": "1", "VI": "1", "VU": "678", "WF": "681",
+		"WS": "685", "YE": "967", "YT": "262", "ZM": "260", "ZW": "263",
+	}
+
+	phonePrimaryCountry = map[string]CountryISO{
+		"1":   "US",
+		"7":   "RU",
+		"212": "MA",
+		"358": "FI",
+		"590": "GP",
+		"599": "CW",
+	}
+)
+
+const maxPhoneDigits = 15
+
+func (p PhoneNumber) E164() string {
+	return "+" + p.CallingCode + p.Subscriber
+}
+
+func (p PhoneNumber) String() string {
+	return p.E164()
+}
+
+func CallingCodeOf(iso CountryISO) (string, bool) {
+	rule, ok := phoneRuleOf(CountryISO(strings.ToUpper(string(iso))))
+	return rule.CallingCode, ok
+}
+
+func ParsePhone(raw string, country CountryISO) (*PhoneNumber, error) {
+	return parsePhone(raw, country, "")
+}
+
+func NormalizePhone(callingCode, phone string, country CountryISO) (*PhoneNumber, error) {
+	phone = strings.TrimSpace(phone)
+	callingCode = strings.TrimLeft(strings.TrimSpace(callingCode), "+0")
+	if len(callingCode) <= 0 || strings.HasPrefix(phone, "+") || strings.HasPrefix(phone, "00") {
+		return parsePhone(phone, country, callingCode)
+	}
+	return parsePhone("+"+callingCode+" "+phone, country, callingCode)
+}
+
+func parsePhone(raw string, country CountryISO, callingCode string) (*PhoneNumber, error) {
+	country = CountryISO(strings.ToUpper(strings.TrimSpace(string(country))))
+
+	international, digits, err := cleanPhone(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	if international {
+		return parseInternationalPhone(digits, country, callingCode)
+	}
+
+	rule, ok := phoneRuleOf(country)
+	if !ok {
+		return nil, ErrPhoneUnknownCountry
+	}
+	return newPhoneNumber(country, rule, stripTrunk(rule, digits, true))
+}
+
+func parseInternationalPhone(digits string, country CountryISO, callingCode string) (*PhoneNumber, error) {
+	if rule, ok := phoneRuleOf(country); ok && strings.HasPrefix(digits, rule.CallingCode) {
+		return newPhoneNumber(country, rule, stripTrunk(rule, strings.TrimPrefix(digits, rule.CallingCode), false))
+	}
+
+	for n := 3; n >= 1; n-- {
+		if len(digits) <= n {
+			continue
+		}
+		iso, ok := countryOfCallingCode(digits[:n])
+		if !ok {
+			continue
+		}
+		rule, _ := phoneRuleOf(iso)
+		return newPhoneNumber(iso, rule, stripTrunk(rule, digits[n:], false))
+	}
+
+	if len(callingCode) > 0 && len(digits) > len(callingCode) && strings.HasPrefix(digits, callingCode) {
+		return newPhoneNumber(country, lenientPhoneRule(callingCode), digits[len(callingCode):])
+	}
+
+	return nil, ErrPhoneUnknownCountry
+}
+
+func phoneRuleOf(country CountryISO) (phoneRule, bool) {
+	if rule, ok := phoneRules[country]; ok {
+		return rule, true
+	}
+	if code, ok := callingCodes[country]; ok {
+		return lenientPhoneRule(code), true
+	}
+	return phoneRule{}, false
+}
+
+func lenientPhoneRule(callingCode string) phoneRule {
+	return phoneRule{CallingCode: callingCode, MinLength: 1, MaxLength: maxPhoneDigits - len(callingCode)}
+}
+
+func newPhoneNumber(country CountryISO, rule phoneRule, subscriber string) (*PhoneNumber, error) {
+	if len(subscriber) < rule.MinLength || len(subscriber) > rule.MaxLength {
+		if rule.MinLength == rule.MaxLength {
+			return nil, fmt.Errorf("phone number for %s must have %d digits", country, rule.MinLength)
+		}
+		return nil, fmt.Errorf("phone number for %s must have %d to %d digits", country, rule.MinLength, rule.MaxLength)
+	}
+	return &PhoneNumber{
+		Country:     country,
+		CallingCode: rule.CallingCode,
+		Subscriber:  subscriber,
+	}, nil
+}
+
+func stripTrunk(rule phoneRule, subscriber string, national bool) string {
+	if len(rule.TrunkPrefix) <= 0 || !strings.HasPrefix(subscriber, rule.TrunkPrefix) || len(subscriber)-len(rule.TrunkPrefix) < rule.MinLength {
+		return subscriber
+	}
+	if national || strings.HasPrefix(rule.TrunkPrefix, "0") || len(subscriber) > rule.MaxLength {
+		return strings.TrimPrefix(subscriber, rule.TrunkPrefix)
+	}
+	return subscriber
+}
+
+func countryOfCallingCode(code string) (CountryISO, bool) {
+	if iso, ok := phonePrimaryCountry[code]; ok {
+		return iso, true
+	}
+	isos := []string{}
+	for iso, rule := range phoneRules {
+		if rule.CallingCode == code {
+			isos = append(isos, string(iso))
+		}
+	}
+	for iso, c := range callingCodes {
+		if c == code {
+			isos = append(isos, string(iso))
+		}
+	}
+	if len(isos) <= 0 {
+		return "", false
+	}
+	sort.Strings(isos)
+	return CountryISO(isos[0]), true
+}
+
+func cleanPhone(raw string) (bool, string, error) {
+	raw = strings.TrimSpace(raw)
+	international := false
+	if strings.HasPrefix(raw, "+") {
+		international = true
+		raw = raw[1:]
+	}
+
+	b := strings.Builder{}
+	for _, r := range raw {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '.' || r == '(' || r == ')' || r == '/':
+		default:
+			return false, "", ErrPhoneInvalid
+		}
+	}
+
+	digits := b.String()
+	if !international && strings.HasPrefix(digits, "00") {
+		international = true
+		digits = digits[2:]
+	}
+	if len(digits) <= 0 {
+		return false, "", ErrPhoneInvalid
+	}
+	return international, digits, nil
+}
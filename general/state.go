@@ -14,12 +14,20 @@ import (
 
 type stateMap map[string]string
 
+type StateResolution struct {
+	State      string
+	OtherState string
+	Matched    bool
+}
+
 type States interface {
 	Name(id string) string
 	Length() int
 	ToMap() map[string]string
 }
 
+const OtherState = "Other"
+
 func fetchStateList(c core.Core, cc CountryISO) (States, error) {
 	data := url.Values{}
 	data.Add("country-code", string(cc))
@@ -77,3 +85,22 @@ func (s stateMap) ToMap() map[string]string {
 func (s stateMap) Length() int {
 	return len(s.ToMap())
 }
+
+func (r *StateResolution) IsOther() bool {
+	return r.State == OtherState && len(r.OtherState) > 0
+}
+
+func ResolveState(states States, state string) *StateResolution {
+	state = strings.TrimSpace(state)
+	if states == nil || states.Length() <= 0 {
+		return &StateResolution{State: state}
+	}
+
+	for id, name := range states.ToMap() {
+		if strings.EqualFold(id, state) || strings.EqualFold(name, state) {
+			return &StateResolution{State: name, Matched: true}
+		}
+	}
+
+	return &StateResolution{State: OtherState, OtherState: state}
+}
@@ -5,4 +5,5 @@ go 1.16
 require (
 	github.com/go-playground/validator/v10 v10.6.1
 	github.com/stretchr/testify v1.4.0
+	golang.org/x/net v0.0.0-20210428140749-89ef3d95e781
 )